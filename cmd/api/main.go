@@ -9,12 +9,17 @@ import (
 	"syscall"
 	"time"
 
+	"go-gin-hexagonal/internal/adapter/captcha"
 	"go-gin-hexagonal/internal/adapter/database/gorm"
+	"go-gin-hexagonal/internal/adapter/export"
+	"go-gin-hexagonal/internal/adapter/geoip"
 	"go-gin-hexagonal/internal/adapter/http/handlers"
 	"go-gin-hexagonal/internal/adapter/http/middleware"
 	"go-gin-hexagonal/internal/adapter/http/routes"
 	"go-gin-hexagonal/internal/adapter/mailer"
+	"go-gin-hexagonal/internal/adapter/scheduler"
 	"go-gin-hexagonal/internal/adapter/security"
+	"go-gin-hexagonal/internal/adapter/storage"
 	"go-gin-hexagonal/internal/application/service"
 	"go-gin-hexagonal/internal/domain/entity"
 
@@ -48,29 +53,92 @@ func main() {
 	// Database adapters
 	userRepo := gorm.NewUserRepository(db, gorm.NewBaseRepository[entity.User](db))
 	refreshTokenRepo := gorm.NewRefreshTokenRepository(db, gorm.NewBaseRepository[entity.RefreshToken](db))
+	invitationRepo := gorm.NewInvitationRepository(db, gorm.NewBaseRepository[entity.Invitation](db))
+	knownDeviceRepo := gorm.NewKnownDeviceRepository(db, gorm.NewBaseRepository[entity.KnownDevice](db))
+	auditEventRepo := gorm.NewAuditEventRepository(db, gorm.NewBaseRepository[entity.AuditEvent](db))
+	importJobRepo := gorm.NewImportJobRepository(db, gorm.NewBaseRepository[entity.ImportJob](db))
+	attributeRepo := gorm.NewAttributeDefinitionRepository(db, gorm.NewBaseRepository[entity.AttributeDefinition](db))
+	groupRepo := gorm.NewGroupRepository(db, gorm.NewBaseRepository[entity.Group](db))
+	preferencesRepo := gorm.NewUserPreferencesRepository(db)
 
 	// Security adapters
 	passwordHasher := security.NewBcryptHasher()
 	tokenManager := security.NewJWTToken(cfg.JWT)
 	encryptor := security.NewAESEncryptor(cfg.AES)
 
+	// GeoIP adapter
+	geoLocator, err := geoip.NewMaxMindLocator(cfg.GeoIP)
+	if err != nil {
+		log.Fatal("Failed to open GeoIP database:", err)
+	}
+	defer geoLocator.Close()
+
+	// CAPTCHA adapter
+	captchaVerifier, err := captcha.NewCaptchaVerifier(cfg.Captcha)
+	if err != nil {
+		log.Fatal("Failed to configure CAPTCHA verifier:", err)
+	}
+
+	// File storage adapter
+	fileStorage, err := storage.NewFileStorage(cfg.Storage)
+	if err != nil {
+		log.Fatal("Failed to configure file storage:", err)
+	}
+
 	// Mailer adapter
 	mailerManager := mailer.NewSMTPMailer(&cfg.Mailer)
 
 	// Init services
-	emailService := service.NewEmailService(mailerManager)
-	authService := service.NewAuthService(userRepo, refreshTokenRepo, tokenManager, passwordHasher, emailService, encryptor)
-	userService := service.NewUserService(userRepo, passwordHasher, emailService)
+	emailService := service.NewEmailService(mailerManager, preferencesRepo)
+	auditService := service.NewAuditService(auditEventRepo, cfg.Audit)
+	registrationPolicy := service.NewRegistrationPolicy(cfg.Registration)
+	usernamePolicy := service.NewUsernamePolicy(cfg.Username)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, tokenManager, passwordHasher, emailService, encryptor, invitationRepo, cfg.Password, knownDeviceRepo, geoLocator, auditService, registrationPolicy, usernamePolicy)
+	userService := service.NewUserService(userRepo, passwordHasher, emailService, invitationRepo, refreshTokenRepo, cfg.Invitation, cfg.Password, registrationPolicy, fileStorage, attributeRepo, cfg.Deletion, encryptor, auditService, usernamePolicy)
+	avatarService := service.NewAvatarService(userRepo, fileStorage, cfg.Avatar)
+	importService := service.NewImportService(importJobRepo, attributeRepo, userService, cfg.Import)
+	exportService := service.NewExportService(userRepo, attributeRepo, export.NewExporter(), cfg.Export)
+	attributeService := service.NewAttributeService(attributeRepo, userRepo)
+	groupService := service.NewGroupService(groupRepo, userRepo, fileStorage)
+	preferencesService := service.NewPreferencesService(preferencesRepo, userRepo)
+
+	// Background jobs
+	jobScheduler := scheduler.NewScheduler()
+	if cfg.Password.MaxAge > 0 {
+		jobScheduler.Every("password-expiry-reminders", 24*time.Hour, userService.SendPasswordExpiryReminders)
+	}
+	jobScheduler.Every("suspension-expiry", 15*time.Minute, userService.LiftExpiredSuspensions)
+	if cfg.Audit.Retention > 0 {
+		jobScheduler.Every("audit-retention", 24*time.Hour, auditService.PruneExpired)
+	}
+	if cfg.Deletion.Retention > 0 {
+		jobScheduler.Every("deleted-user-purge", 24*time.Hour, userService.PurgeDeletedUsers)
+	}
+	if cfg.Username.AliasRetention > 0 {
+		jobScheduler.Every("username-alias-purge", 24*time.Hour, userService.PurgeExpiredUsernameAliases)
+	}
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	jobScheduler.Start(jobCtx)
 
 	// Init Handlers
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(authService, cfg.Cookie)
 	userHandler := handlers.NewUserHandler(userService)
+	avatarHandler := handlers.NewAvatarHandler(avatarService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	importHandler := handlers.NewImportHandler(importService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	attributeHandler := handlers.NewAttributeHandler(attributeService)
+	groupHandler := handlers.NewGroupHandler(groupService)
+	preferencesHandler := handlers.NewPreferencesHandler(preferencesService)
 
 	// Init middleware
-	authMiddleware := middleware.NewAuthMiddleware(tokenManager)
+	authMiddleware := middleware.NewAuthMiddleware(tokenManager, cfg.Cookie)
+	captchaMiddleware := middleware.NewCaptchaMiddleware(captchaVerifier, cfg.Captcha)
+	groupMiddleware := middleware.NewGroupMiddleware(groupService)
 
 	// Init router
-	appRouter := routes.NewRouter(authHandler, userHandler, authMiddleware)
+	appRouter := routes.NewRouter(authHandler, userHandler, avatarHandler, auditHandler, importHandler, exportHandler, attributeHandler, groupHandler, preferencesHandler, authMiddleware, captchaMiddleware, groupMiddleware, cfg.Cookie, cfg.Storage, cfg.Export)
 	ginRouter := appRouter.SetupRoutes()
 
 	srv := &http.Server{
@@ -99,5 +167,8 @@ func main() {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	stopJobs()
+	jobScheduler.Wait()
+
 	log.Println("Server exiting")
 }
@@ -32,9 +32,9 @@ func main() {
 	case "--migrate":
 		gorm.RunMigrations(db)
 	case "--seed":
-		gorm.RunSeeders(db)
+		gorm.RunSeeders(db, cfg.Seed)
 	case "--fresh":
-		gorm.RunFreshMigrations(db)
+		gorm.RunFreshMigrations(db, cfg.Seed)
 	default:
 		log.Println("Unknown command. Use --migrate, --seed, or --fresh.")
 		return
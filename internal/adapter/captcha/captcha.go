@@ -0,0 +1,69 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+)
+
+const (
+	ProviderHCaptcha   = "hcaptcha"
+	ProviderTurnstile  = "turnstile"
+	ProviderAlwaysPass = "always-pass"
+	ProviderAlwaysFail = "always-fail"
+)
+
+// NewCaptchaVerifier builds the verifier for the configured provider. It
+// returns a nil verifier when no provider is configured.
+func NewCaptchaVerifier(cfg config.CaptchaConfig) (ports.CaptchaVerifier, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "":
+		return nil, nil
+	case ProviderHCaptcha:
+		return NewHCaptchaVerifier(cfg), nil
+	case ProviderTurnstile:
+		return NewTurnstileVerifier(cfg), nil
+	case ProviderAlwaysPass:
+		return NewStaticVerifier(true), nil
+	case ProviderAlwaysFail:
+		return NewStaticVerifier(false), nil
+	default:
+		return nil, fmt.Errorf("unknown captcha provider: %s", cfg.Provider)
+	}
+}
+
+// siteVerify posts a token to a siteverify endpoint. hCaptcha and Turnstile
+// share the same request format.
+func siteVerify(ctx context.Context, client *http.Client, verifyURL string, form url.Values, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha siteverify returned status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &http.Client{Timeout: timeout}
+}
@@ -0,0 +1,66 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+)
+
+const hCaptchaVerifyURL = "https://api.hcaptcha.com/siteverify"
+
+type HCaptchaVerifier struct {
+	secret    string
+	siteKey   string
+	verifyURL string
+	client    *http.Client
+}
+
+func NewHCaptchaVerifier(cfg config.CaptchaConfig) ports.CaptchaVerifier {
+	verifyURL := cfg.VerifyURL
+	if verifyURL == "" {
+		verifyURL = hCaptchaVerifyURL
+	}
+
+	return &HCaptchaVerifier{
+		secret:    cfg.Secret,
+		siteKey:   cfg.SiteKey,
+		verifyURL: verifyURL,
+		client:    newHTTPClient(cfg.Timeout),
+	}
+}
+
+type hCaptchaResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+func (v *HCaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) (*ports.CaptchaResult, error) {
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	if v.siteKey != "" {
+		form.Set("sitekey", v.siteKey)
+	}
+
+	var resp hCaptchaResponse
+	if err := siteVerify(ctx, v.client, v.verifyURL, form, &resp); err != nil {
+		return nil, err
+	}
+
+	// Risk scores are only returned by hCaptcha Enterprise, where higher
+	// scores already mean riskier requests.
+	result := &ports.CaptchaResult{Success: resp.Success}
+	if resp.Score != nil {
+		result.RiskScore = *resp.Score
+	}
+
+	return result, nil
+}
@@ -0,0 +1,24 @@
+package captcha
+
+import (
+	"context"
+
+	"go-gin-hexagonal/internal/domain/ports"
+)
+
+// StaticVerifier accepts or rejects every token without calling a provider.
+// It stands in for a real provider in tests and local development.
+type StaticVerifier struct {
+	pass bool
+}
+
+func NewStaticVerifier(pass bool) ports.CaptchaVerifier {
+	return &StaticVerifier{pass: pass}
+}
+
+func (v *StaticVerifier) Verify(ctx context.Context, token, remoteIP string) (*ports.CaptchaResult, error) {
+	if !v.pass {
+		return &ports.CaptchaResult{Success: false, RiskScore: 1}, nil
+	}
+	return &ports.CaptchaResult{Success: true}, nil
+}
@@ -0,0 +1,55 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+)
+
+const turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+
+type TurnstileVerifier struct {
+	secret    string
+	verifyURL string
+	client    *http.Client
+}
+
+func NewTurnstileVerifier(cfg config.CaptchaConfig) ports.CaptchaVerifier {
+	verifyURL := cfg.VerifyURL
+	if verifyURL == "" {
+		verifyURL = turnstileVerifyURL
+	}
+
+	return &TurnstileVerifier{
+		secret:    cfg.Secret,
+		verifyURL: verifyURL,
+		client:    newHTTPClient(cfg.Timeout),
+	}
+}
+
+type turnstileResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify checks a Turnstile token. Turnstile does not expose a risk score, so
+// successful verifications are reported as risk free.
+func (v *TurnstileVerifier) Verify(ctx context.Context, token, remoteIP string) (*ports.CaptchaResult, error) {
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	var resp turnstileResponse
+	if err := siteVerify(ctx, v.client, v.verifyURL, form, &resp); err != nil {
+		return nil, err
+	}
+
+	return &ports.CaptchaResult{Success: resp.Success}, nil
+}
@@ -0,0 +1,67 @@
+package gorm
+
+import (
+	"context"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttributeDefinitionRepository struct {
+	db       *gorm.DB
+	baseRepo repositories.BaseRepository[entity.AttributeDefinition]
+}
+
+func NewAttributeDefinitionRepository(db *gorm.DB, baseRepo repositories.BaseRepository[entity.AttributeDefinition]) repositories.AttributeDefinitionRepository {
+	return &AttributeDefinitionRepository{db: db, baseRepo: baseRepo}
+}
+
+func (r *AttributeDefinitionRepository) Create(ctx context.Context, definition *entity.AttributeDefinition) (*entity.AttributeDefinition, error) {
+	if definition.ID == uuid.Nil {
+		definition.ID = uuid.New()
+	}
+	return r.baseRepo.Create(ctx, definition)
+}
+
+func (r *AttributeDefinitionRepository) Update(ctx context.Context, definition *entity.AttributeDefinition) (*entity.AttributeDefinition, error) {
+	return r.baseRepo.Update(ctx, definition)
+}
+
+// Delete removes the definition outright rather than soft deleting it so the
+// key can be defined again.
+func (r *AttributeDefinitionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var definition entity.AttributeDefinition
+		if err := tx.Where("id = ?", id).Take(&definition).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.User{}).
+			Where("attributes -> ? IS NOT NULL", definition.Key).
+			Updates(map[string]any{"attributes": gorm.Expr("attributes - ?", definition.Key), "version": nextVersion}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&entity.AttributeDefinition{}, "id = ?", id).Error
+	})
+}
+
+func (r *AttributeDefinitionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error) {
+	return r.baseRepo.FindByID(ctx, id)
+}
+
+func (r *AttributeDefinitionRepository) FindByKey(ctx context.Context, key string) (*entity.AttributeDefinition, error) {
+	return r.baseRepo.FindFirst(ctx, "key = ?", key)
+}
+
+func (r *AttributeDefinitionRepository) FindAll(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	var definitions []*entity.AttributeDefinition
+	if err := r.db.WithContext(ctx).Order(clause.OrderByColumn{Column: clause.Column{Name: "key"}}).Find(&definitions).Error; err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
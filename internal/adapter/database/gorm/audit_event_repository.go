@@ -0,0 +1,72 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditEventRepository struct {
+	db       *gorm.DB
+	baseRepo repositories.BaseRepository[entity.AuditEvent]
+}
+
+func NewAuditEventRepository(db *gorm.DB, baseRepo repositories.BaseRepository[entity.AuditEvent]) repositories.AuditEventRepository {
+	return &AuditEventRepository{db: db, baseRepo: baseRepo}
+}
+
+func (r *AuditEventRepository) Create(ctx context.Context, event *entity.AuditEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	_, err := r.baseRepo.Create(ctx, event)
+	return err
+}
+
+// FindAll returns the matching events, newest first.
+func (r *AuditEventRepository) FindAll(ctx context.Context, filter *repositories.AuditEventFilter, limit, offset int) ([]*entity.AuditEvent, int64, error) {
+	var events []*entity.AuditEvent
+	var count int64
+
+	q := r.db.WithContext(ctx).Model(&entity.AuditEvent{})
+	if filter.ActorID != nil {
+		q = q.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.TargetID != nil {
+		q = q.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.EventType != "" {
+		q = q.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Outcome != "" {
+		q = q.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at < ?", *filter.To)
+	}
+
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := q.Order("created_at desc, id desc").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
+
+func (r *AuditEventRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&entity.AuditEvent{})
+	return result.RowsAffected, result.Error
+}
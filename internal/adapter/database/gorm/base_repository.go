@@ -2,18 +2,50 @@ package gorm
 
 import (
 	"context"
+	"reflect"
+	"slices"
+	"time"
+
 	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/pkg/errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BaseRepository[T any] struct {
 	db *gorm.DB
+	// softDelete is set for entities embedding entity.AuditInfo. Their rows
+	// are only flagged as deleted, and default queries skip them.
+	softDelete bool
+	// versioned is also set for entities embedding entity.AuditInfo. Their
+	// updates only apply to the version they were read at.
+	versioned bool
 }
 
 func NewBaseRepository[T any](db *gorm.DB) repositories.BaseRepository[T] {
-	return &BaseRepository[T]{db: db}
+	_, softDelete := reflect.TypeFor[T]().FieldByName("IsDeleted")
+	_, versioned := reflect.TypeFor[T]().FieldByName("Version")
+	return &BaseRepository[T]{db: db, softDelete: softDelete, versioned: versioned}
+}
+
+// nextVersion is the update that moves a row to its next version.
+var nextVersion = gorm.Expr("version + 1")
+
+// notDeleted restricts q to rows that have not been soft deleted.
+func notDeleted(q *gorm.DB) *gorm.DB {
+	return q.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "is_deleted"}, Value: false})
+}
+
+// query starts a statement that skips soft deleted rows unless
+// includeDeleted is set or T is never soft deleted.
+func (r *BaseRepository[T]) query(ctx context.Context, includeDeleted bool) *gorm.DB {
+	q := r.db.WithContext(ctx)
+	if r.softDelete && !includeDeleted {
+		q = notDeleted(q)
+	}
+	return q
 }
 
 func (r *BaseRepository[T]) Raw(ctx context.Context, query string) ([]*T, error) {
@@ -37,7 +69,7 @@ func (r *BaseRepository[T]) FindAll(ctx context.Context, limit, offset int, quer
 	}
 
 	var entity T
-	q := r.db.WithContext(ctx).Model(&entity)
+	q := r.query(ctx, false).Model(&entity)
 	q = q.Where(query, args...)
 
 	if err := q.Count(&count).Error; err != nil {
@@ -51,9 +83,114 @@ func (r *BaseRepository[T]) FindAll(ctx context.Context, limit, offset int, quer
 	return entities, count, nil
 }
 
+// FindPage returns one page using keyset pagination. Instead of an offset the
+// cursor carries the sort key of the last row seen, so pages need no COUNT and
+// stay consistent while rows are inserted or deleted.
+func (r *BaseRepository[T]) FindPage(ctx context.Context, page *repositories.CursorQuery, query any, args ...any) (*repositories.CursorPage[T], error) {
+	limit := page.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	column := page.Column
+	if column == "" {
+		column = "id"
+	}
+
+	var from *cursor
+	if page.Cursor != "" {
+		decoded, err := decodeCursor(page.Cursor)
+		if err != nil || decoded.Column != column {
+			return nil, errors.ErrInvalidCursor
+		}
+		from = decoded
+	}
+	backward := from != nil && from.Backward
+
+	// Walking backwards flips the order so the rows closest to the cursor
+	// come first; they are put back in order below.
+	desc := page.Desc != backward
+	orderBy := []clause.OrderByColumn{{Column: clause.Column{Name: column}, Desc: desc}}
+	if column != "id" {
+		orderBy = append(orderBy, clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc})
+	}
+
+	var entity T
+	q := r.query(ctx, page.IncludeDeleted).Model(&entity)
+	if query != nil {
+		q = q.Where(query, args...)
+	}
+	if from != nil {
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		if column == "id" {
+			q = q.Where(clause.Expr{SQL: "? " + op + " ?", Vars: []any{clause.Column{Name: "id"}, from.ID}})
+		} else {
+			q = q.Where(clause.Expr{
+				SQL:  "(?, ?) " + op + " (?, ?)",
+				Vars: []any{clause.Column{Name: column}, clause.Column{Name: "id"}, from.Value, from.ID},
+			})
+		}
+	}
+
+	// One extra row tells whether there is another page in this direction.
+	var entities []*T
+	if err := q.Clauses(clause.OrderBy{Columns: orderBy}).Limit(limit + 1).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	hasMore := len(entities) > limit
+	if hasMore {
+		entities = entities[:limit]
+	}
+	if backward {
+		slices.Reverse(entities)
+	}
+
+	result := &repositories.CursorPage[T]{Items: entities}
+	if len(entities) == 0 {
+		return result, nil
+	}
+
+	var err error
+	if (backward && hasMore) || (!backward && from != nil) {
+		if result.PrevCursor, err = r.cursorAt(ctx, entities[0], column, true); err != nil {
+			return nil, err
+		}
+	}
+	if (!backward && hasMore) || backward {
+		if result.NextCursor, err = r.cursorAt(ctx, entities[len(entities)-1], column, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// cursorAt builds the cursor pointing at entity.
+func (r *BaseRepository[T]) cursorAt(ctx context.Context, entity *T, column string, backward bool) (string, error) {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(entity); err != nil {
+		return "", err
+	}
+
+	sortField := stmt.Schema.LookUpField(column)
+	idField := stmt.Schema.LookUpField("id")
+	if sortField == nil || idField == nil {
+		return "", errors.ErrInvalidSortField
+	}
+
+	value := reflect.ValueOf(entity)
+	sortValue, _ := sortField.ValueOf(ctx, value)
+	idValue, _ := idField.ValueOf(ctx, value)
+
+	return encodeCursor(&cursor{Column: column, Value: sortValue, ID: idValue, Backward: backward})
+}
+
 func (r *BaseRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, error) {
 	var entity T
-	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(&entity).Error; err != nil {
+	if err := r.query(ctx, false).Where("id = ?", id).Take(&entity).Error; err != nil {
 		return nil, err
 	}
 	return &entity, nil
@@ -61,7 +198,7 @@ func (r *BaseRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, err
 
 func (r *BaseRepository[T]) FindFirst(ctx context.Context, query any, args ...any) (*T, error) {
 	var entity T
-	if err := r.db.WithContext(ctx).Where(query, args...).First(&entity).Error; err != nil {
+	if err := r.query(ctx, false).Where(query, args...).First(&entity).Error; err != nil {
 		return nil, err
 	}
 	return &entity, nil
@@ -69,7 +206,7 @@ func (r *BaseRepository[T]) FindFirst(ctx context.Context, query any, args ...an
 
 func (r *BaseRepository[T]) Where(ctx context.Context, query any, args ...any) ([]*T, error) {
 	var entities []*T
-	if err := r.db.WithContext(ctx).Where(query, args...).Order("id asc").Find(&entities).Error; err != nil {
+	if err := r.query(ctx, false).Where(query, args...).Order("id asc").Find(&entities).Error; err != nil {
 		return nil, err
 	}
 	return entities, nil
@@ -77,7 +214,7 @@ func (r *BaseRepository[T]) Where(ctx context.Context, query any, args ...any) (
 
 func (r *BaseRepository[T]) WhereExisting(ctx context.Context, query any, args ...any) (bool, error) {
 	var entity T
-	err := r.db.WithContext(ctx).Where(query, args...).First(&entity).Error
+	err := r.query(ctx, false).Where(query, args...).First(&entity).Error
 	if err == gorm.ErrRecordNotFound {
 		return false, nil
 	}
@@ -88,6 +225,12 @@ func (r *BaseRepository[T]) WhereExisting(ctx context.Context, query any, args .
 }
 
 func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) (*T, error) {
+	if r.versioned {
+		if version := reflect.ValueOf(entity).Elem().FieldByName("Version"); version.Int() == 0 {
+			version.SetInt(1)
+		}
+	}
+
 	if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
 		return nil, err
 	}
@@ -96,7 +239,33 @@ func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) (*T, error) {
 }
 
 func (r *BaseRepository[T]) Update(ctx context.Context, entity *T) (*T, error) {
-	if err := r.db.WithContext(ctx).Updates(&entity).Error; err != nil {
+	// Select("*") writes zero values too, so flags can be switched off and
+	// nullable columns cleared. Deletion state is left to Delete and Restore
+	// so a stale copy cannot undo a delete.
+	omit := []string{clause.Associations, "CreatedAt"}
+	if r.softDelete {
+		omit = append(omit, "DeletedAt", "IsDeleted")
+	}
+
+	q := r.db.WithContext(ctx).Model(entity)
+	var version reflect.Value
+	if r.versioned {
+		// Checking the version in the same statement that bumps it makes
+		// the check atomic; a concurrent writer leaves no row to match.
+		version = reflect.ValueOf(entity).Elem().FieldByName("Version")
+		q = q.Where("version = ?", version.Int())
+		version.SetInt(version.Int() + 1)
+	}
+
+	result := q.Select("*").Omit(omit...).Updates(entity)
+	err := result.Error
+	if err == nil && r.versioned && result.RowsAffected == 0 {
+		err = errors.ErrVersionConflict
+	}
+	if err != nil {
+		if r.versioned {
+			version.SetInt(version.Int() - 1)
+		}
 		return nil, err
 	}
 
@@ -107,10 +276,72 @@ func (r *BaseRepository[T]) Update(ctx context.Context, entity *T) (*T, error) {
 	return entity, nil
 }
 
+// Delete soft deletes the row when T supports it and removes it otherwise.
 func (r *BaseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(new(T), "id = ?", id).Error; err != nil {
-		return err
+	if !r.softDelete {
+		return r.db.WithContext(ctx).Delete(new(T), "id = ?", id).Error
 	}
 
+	result := r.query(ctx, false).Model(new(T)).Where("id = ?", id).Updates(map[string]any{
+		"deleted_at": time.Now(),
+		"is_deleted": true,
+		"version":    nextVersion,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
 	return nil
 }
+
+// FindDeletedByID returns a soft deleted row.
+func (r *BaseRepository[T]) FindDeletedByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	var entity T
+	if !r.softDelete {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err := r.db.WithContext(ctx).Where("id = ? AND is_deleted = true", id).Take(&entity).Error; err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// Restore undoes a soft delete.
+func (r *BaseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	if !r.softDelete {
+		return gorm.ErrRecordNotFound
+	}
+
+	result := r.db.WithContext(ctx).Model(new(T)).Where("id = ? AND is_deleted = true", id).Updates(map[string]any{
+		"deleted_at": nil,
+		"is_deleted": false,
+		"version":    nextVersion,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge permanently removes rows soft deleted before deletedBefore and
+// returns them so callers can clean up what lives outside the database.
+func (r *BaseRepository[T]) Purge(ctx context.Context, deletedBefore time.Time) ([]*T, error) {
+	var entities []*T
+	if !r.softDelete {
+		return entities, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("is_deleted = true AND deleted_at < ?", deletedBefore).
+		Delete(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
@@ -0,0 +1,36 @@
+package gorm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// cursor marks the row a keyset page continues from. It is handed to clients
+// as opaque base64 so the encoding can change without breaking the API.
+type cursor struct {
+	Column   string `json:"c"`
+	Value    any    `json:"v"`
+	ID       any    `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+func encodeCursor(c *cursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(encoded string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"go-gin-hexagonal/internal/adapter/database/gorm/schema"
 	"go-gin-hexagonal/internal/adapter/database/gorm/seeder"
+	"go-gin-hexagonal/internal/domain/entity"
 	"go-gin-hexagonal/pkg/config"
 
 	"gorm.io/driver/postgres"
@@ -16,18 +17,36 @@ import (
 var (
 	// Enums
 	enums = map[string][]string{
-		// Example:
-		// "gender": {
-		// 	"Male",
-		// 	"Female",
-		// 	"Prefer not to say",
-		// },
+		"user_status": entity.UserStatuses,
+	}
+
+	// Extensions
+	extensions = []string{
+		"pg_trgm",
 	}
 
 	// Models
 	models = []any{
 		&schema.User{},
 		&schema.RefreshToken{},
+		&schema.Invitation{},
+		&schema.KnownDevice{},
+		&schema.AuditEvent{},
+		&schema.ImportJob{},
+		&schema.ImportJobRow{},
+		&schema.AttributeDefinition{},
+		&schema.Group{},
+		&schema.GroupMember{},
+		&schema.UserPreferences{},
+		&schema.UsernameAlias{},
+	}
+
+	// Data migrations run after AutoMigrate and must be safe to re-run.
+	dataMigrations = []func(db *gorm.DB) error{
+		backfillPasswordChangedAt,
+		migrateUserStatus,
+		backfillIsDeleted,
+		createUserSearchIndexes,
 	}
 )
 
@@ -63,12 +82,23 @@ func NewPostgresConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 func RunMigrations(db *gorm.DB) error {
 	log.Println("Running database migrations...")
 
+	for _, name := range extensions {
+		if err := db.Exec("CREATE EXTENSION IF NOT EXISTS " + name).Error; err != nil {
+			log.Print(err)
+			return err
+		}
+	}
+
 	for name, values := range enums {
 		quotedValues := make([]string, len(values))
 		for i, value := range values {
 			quotedValues[i] = "'" + value + "'"
 		}
-		if err := db.Exec("CREATE TYPE " + name + " AS ENUM (" + strings.Join(quotedValues, ", ") + ")").Error; err != nil {
+		// Postgres has no CREATE TYPE IF NOT EXISTS, so swallow the duplicate
+		// error to keep migrations re-runnable.
+		createType := "DO $$ BEGIN CREATE TYPE " + name + " AS ENUM (" + strings.Join(quotedValues, ", ") + "); " +
+			"EXCEPTION WHEN duplicate_object THEN NULL; END $$"
+		if err := db.Exec(createType).Error; err != nil {
 			log.Print(err)
 			return err
 		}
@@ -82,11 +112,17 @@ func RunMigrations(db *gorm.DB) error {
 		return err
 	}
 
+	for _, migrate := range dataMigrations {
+		if err := migrate(db); err != nil {
+			return err
+		}
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
 
-func RunSeeders(db *gorm.DB) {
+func RunSeeders(db *gorm.DB, seedConfig config.SeedConfig) {
 	log.Println("Running database seeders...")
 
 	err := seeder.UserSeeder(db)
@@ -94,10 +130,15 @@ func RunSeeders(db *gorm.DB) {
 		log.Printf("Error seeding user data: %v", err)
 	}
 
+	err = seeder.AdminSeeder(db, seedConfig)
+	if err != nil {
+		log.Printf("Error seeding admin: %v", err)
+	}
+
 	log.Println("Database seeding completed successfully")
 }
 
-func RunFreshMigrations(db *gorm.DB) {
+func RunFreshMigrations(db *gorm.DB, seedConfig config.SeedConfig) {
 	log.Println("Running fresh migrations...")
 
 	err := db.Migrator().DropTable(
@@ -122,5 +163,5 @@ func RunFreshMigrations(db *gorm.DB) {
 		return
 	}
 
-	RunSeeders(db)
+	RunSeeders(db, seedConfig)
 }
@@ -0,0 +1,153 @@
+package gorm
+
+import (
+	"context"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// subgroupsQuery selects the id of a group and of every group nested below
+// it. UNION rather than UNION ALL stops the recursion should a cycle ever
+// make it into the table.
+const subgroupsQuery = `
+WITH RECURSIVE subgroups AS (
+	SELECT id FROM groups WHERE id = ?
+	UNION
+	SELECT g.id FROM groups g JOIN subgroups s ON g.parent_id = s.id
+)
+SELECT id FROM subgroups`
+
+// userGroupsQuery selects the ids of the groups a user was added to and of
+// every group above them.
+const userGroupsQuery = `
+WITH RECURSIVE memberships AS (
+	SELECT group_id AS id FROM group_members WHERE user_id = ?
+	UNION
+	SELECT g.parent_id FROM groups g JOIN memberships m ON g.id = m.id WHERE g.parent_id IS NOT NULL
+)
+SELECT id FROM memberships`
+
+type GroupRepository struct {
+	db       *gorm.DB
+	baseRepo repositories.BaseRepository[entity.Group]
+}
+
+func NewGroupRepository(db *gorm.DB, baseRepo repositories.BaseRepository[entity.Group]) repositories.GroupRepository {
+	return &GroupRepository{db: db, baseRepo: baseRepo}
+}
+
+func (r *GroupRepository) Create(ctx context.Context, group *entity.Group) (*entity.Group, error) {
+	if group.ID == uuid.Nil {
+		group.ID = uuid.New()
+	}
+	return r.baseRepo.Create(ctx, group)
+}
+
+func (r *GroupRepository) Update(ctx context.Context, group *entity.Group) (*entity.Group, error) {
+	return r.baseRepo.Update(ctx, group)
+}
+
+// Delete removes the group outright rather than soft deleting it so the name
+// can be used again. Memberships go with it through the foreign key.
+func (r *GroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.Group{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *GroupRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Group, error) {
+	return r.baseRepo.FindByID(ctx, id)
+}
+
+func (r *GroupRepository) FindByName(ctx context.Context, name string) (*entity.Group, error) {
+	return r.baseRepo.FindFirst(ctx, "name = ?", name)
+}
+
+func (r *GroupRepository) FindAll(ctx context.Context) ([]*entity.Group, error) {
+	var groups []*entity.Group
+	if err := r.db.WithContext(ctx).Order(clause.OrderByColumn{Column: clause.Column{Name: "name"}}).Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *GroupRepository) FindDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Raw(subgroupsQuery, id).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+
+	descendants := make([]uuid.UUID, 0, len(ids))
+	for _, descendant := range ids {
+		if descendant != id {
+			descendants = append(descendants, descendant)
+		}
+	}
+	return descendants, nil
+}
+
+func (r *GroupRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Group, error) {
+	var groups []*entity.Group
+	err := r.db.WithContext(ctx).
+		Where("id IN (?)", gorm.Expr(userGroupsQuery, userID)).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "name"}}).
+		Find(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *GroupRepository) AddMember(ctx context.Context, member *entity.GroupMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.GroupMember{}, "group_id = ? AND user_id = ?", groupID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *GroupRepository) FindMember(ctx context.Context, groupID, userID uuid.UUID) (*entity.GroupMember, error) {
+	var member entity.GroupMember
+	if err := r.db.WithContext(ctx).Where("group_id = ? AND user_id = ?", groupID, userID).Take(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *GroupRepository) FindMembers(ctx context.Context, groupID uuid.UUID, nested bool, limit, offset int) ([]*entity.User, int64, error) {
+	groups := gorm.Expr("?", groupID)
+	if nested {
+		groups = gorm.Expr(subgroupsQuery, groupID)
+	}
+
+	q := notDeleted(r.db.WithContext(ctx).Model(&entity.User{})).
+		Where("id IN (?)", gorm.Expr("SELECT user_id FROM group_members WHERE group_id IN (?)", groups))
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []*entity.User
+	if err := q.Order("email asc").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
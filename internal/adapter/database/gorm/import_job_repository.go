@@ -0,0 +1,65 @@
+package gorm
+
+import (
+	"context"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImportJobRepository struct {
+	db       *gorm.DB
+	baseRepo repositories.BaseRepository[entity.ImportJob]
+}
+
+func NewImportJobRepository(db *gorm.DB, baseRepo repositories.BaseRepository[entity.ImportJob]) repositories.ImportJobRepository {
+	return &ImportJobRepository{db: db, baseRepo: baseRepo}
+}
+
+func (r *ImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	return r.baseRepo.Create(ctx, job)
+}
+
+func (r *ImportJobRepository) Update(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	return r.baseRepo.Update(ctx, job)
+}
+
+func (r *ImportJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportJob, error) {
+	return r.baseRepo.FindByID(ctx, id)
+}
+
+func (r *ImportJobRepository) CreateRows(ctx context.Context, rows []*entity.ImportJobRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	for _, row := range rows {
+		if row.ID == uuid.Nil {
+			row.ID = uuid.New()
+		}
+	}
+	return r.db.WithContext(ctx).CreateInBatches(rows, 100).Error
+}
+
+func (r *ImportJobRepository) FindRows(ctx context.Context, jobID uuid.UUID, limit, offset int) ([]*entity.ImportJobRow, int64, error) {
+	var rows []*entity.ImportJobRow
+	var count int64
+
+	q := r.db.WithContext(ctx).Model(&entity.ImportJobRow{}).Where("import_job_id = ?", jobID)
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := q.Order(clause.OrderByColumn{Column: clause.Column{Name: "row"}}).Limit(limit).Offset(offset).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return rows, count, nil
+}
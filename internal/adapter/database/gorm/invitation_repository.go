@@ -0,0 +1,47 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type InvitationRepository struct {
+	db       *gorm.DB
+	baseRepo repositories.BaseRepository[entity.Invitation]
+}
+
+func NewInvitationRepository(db *gorm.DB, baseRepo repositories.BaseRepository[entity.Invitation]) repositories.InvitationRepository {
+	return &InvitationRepository{db: db, baseRepo: baseRepo}
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, invitation *entity.Invitation) (*entity.Invitation, error) {
+	if invitation.ID == uuid.Nil {
+		invitation.ID = uuid.New()
+	}
+	return r.baseRepo.Create(ctx, invitation)
+}
+
+func (r *InvitationRepository) Update(ctx context.Context, invitation *entity.Invitation) (*entity.Invitation, error) {
+	return r.baseRepo.Update(ctx, invitation)
+}
+
+func (r *InvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	return r.baseRepo.FindFirst(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *InvitationRepository) FindPendingByUserID(ctx context.Context, userID uuid.UUID) (*entity.Invitation, error) {
+	return r.baseRepo.FindFirst(ctx, "user_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", userID, time.Now())
+}
+
+func (r *InvitationRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.Invitation{}).
+		Where("user_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", userID).
+		Updates(map[string]any{"revoked_at": time.Now(), "version": nextVersion}).Error
+}
@@ -0,0 +1,41 @@
+package gorm
+
+import (
+	"context"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type KnownDeviceRepository struct {
+	db       *gorm.DB
+	baseRepo repositories.BaseRepository[entity.KnownDevice]
+}
+
+func NewKnownDeviceRepository(db *gorm.DB, baseRepo repositories.BaseRepository[entity.KnownDevice]) repositories.KnownDeviceRepository {
+	return &KnownDeviceRepository{db: db, baseRepo: baseRepo}
+}
+
+func (r *KnownDeviceRepository) Create(ctx context.Context, device *entity.KnownDevice) (*entity.KnownDevice, error) {
+	if device.ID == uuid.Nil {
+		device.ID = uuid.New()
+	}
+	return r.baseRepo.Create(ctx, device)
+}
+
+func (r *KnownDeviceRepository) Update(ctx context.Context, device *entity.KnownDevice) (*entity.KnownDevice, error) {
+	return r.baseRepo.Update(ctx, device)
+}
+
+func (r *KnownDeviceRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.KnownDevice, error) {
+	return r.baseRepo.Where(ctx, "user_id = ?", userID)
+}
+
+func (r *KnownDeviceRepository) DeleteAllByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&entity.KnownDevice{}).Error
+}
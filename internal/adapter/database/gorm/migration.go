@@ -0,0 +1,66 @@
+package gorm
+
+import (
+	"go-gin-hexagonal/internal/adapter/database/gorm/schema"
+
+	"gorm.io/gorm"
+)
+
+// backfillPasswordChangedAt treats existing passwords as set when the account
+// was created so that expiry policies do not lock out every existing user.
+func backfillPasswordChangedAt(db *gorm.DB) error {
+	return db.Exec("UPDATE users SET password_changed_at = created_at WHERE password_changed_at IS NULL AND password <> ''").Error
+}
+
+// createUserSearchIndexes indexes the user search document for full text
+// search and the searched columns for trigram matching.
+func createUserSearchIndexes(db *gorm.DB) error {
+	statements := []string{
+		"CREATE INDEX IF NOT EXISTS idx_users_search_document ON users USING gin ((" + userSearchDocument + "))",
+		"CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin (name gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING gin (username gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (email gin_trgm_ops)",
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// softDeleteTables are the tables whose rows embed AuditInfo.
+var softDeleteTables = []string{"users", "refresh_tokens", "invitations", "known_devices", "import_jobs", "attribute_definitions"}
+
+// backfillIsDeleted flags rows that only had deleted_at set, which soft
+// delete queries would otherwise still return.
+func backfillIsDeleted(db *gorm.DB) error {
+	for _, table := range softDeleteTables {
+		if err := db.Exec("UPDATE " + table + " SET is_deleted = true WHERE deleted_at IS NOT NULL AND is_deleted = false").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateUserStatus replaces the legacy is_active flag with the status column.
+// Active users become active, rejected registrations deactivated, and everyone
+// else keeps the pending_verification default. The flag is dropped afterwards,
+// so this only runs once.
+func migrateUserStatus(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&schema.User{}, "is_active") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE users SET status = 'active', status_changed_at = updated_at WHERE is_active = true").Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("UPDATE users SET status = 'deactivated', status_reason = approval_reason, status_changed_at = approval_decided_at WHERE is_active = false AND approval_status = 'rejected'").Error; err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn(&schema.User{}, "is_active")
+	})
+}
@@ -40,14 +40,15 @@ func (r *RefreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID u
 	return r.db.WithContext(ctx).
 		Model(&entity.RefreshToken{}).
 		Where("user_id = ? AND is_revoked = false", userID).
-		Update("is_revoked", true).Error
+		Updates(map[string]any{"is_revoked": true, "version": nextVersion}).Error
 }
 
-func (r *RefreshTokenRepository) RevokeByToken(ctx context.Context, token string) error {
-	return r.db.WithContext(ctx).
+func (r *RefreshTokenRepository) RevokeByToken(ctx context.Context, token string) (int64, error) {
+	result := r.db.WithContext(ctx).
 		Model(&entity.RefreshToken{}).
-		Where("token = ?", token).
-		Update("is_revoked", true).Error
+		Where("token = ? AND is_revoked = false", token).
+		Updates(map[string]any{"is_revoked": true, "version": nextVersion})
+	return result.RowsAffected, result.Error
 }
 
 func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) error {
@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AttributeDefinition struct {
+	ID       uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Key      string    `json:"key" gorm:"unique;not null;type:varchar(50)"`
+	Label    string    `json:"label" gorm:"type:varchar(100);not null"`
+	Type     string    `json:"type" gorm:"type:varchar(16);not null"`
+	Required bool      `json:"required" gorm:"default:false"`
+	Unique   bool      `json:"unique" gorm:"default:false"`
+	Pattern  string    `json:"pattern,omitempty" gorm:"type:varchar(255)"`
+
+	AuditInfo
+}
+
+func (d *AttributeDefinition) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+func (AttributeDefinition) TableName() string {
+	return "attribute_definitions"
+}
@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditEvent struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty" gorm:"type:uuid;index"`
+	TargetID  *uuid.UUID `json:"target_id,omitempty" gorm:"type:uuid;index"`
+	EventType string     `json:"event_type" gorm:"type:varchar(64);not null;index"`
+	Outcome   string     `json:"outcome" gorm:"type:varchar(16);not null"`
+	Reason    string     `json:"reason,omitempty" gorm:"type:text"`
+	IPAddress string     `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent string     `json:"user_agent" gorm:"type:text"`
+	RequestID string     `json:"request_id" gorm:"type:varchar(64);index"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+func (e *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -9,4 +9,5 @@ type AuditInfo struct {
 	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 	IsDeleted bool       `json:"is_deleted" gorm:"default:false"`
+	Version   int        `json:"version" gorm:"not null;default:1"`
 }
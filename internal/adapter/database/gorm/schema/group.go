@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Group struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	Name        string     `json:"name" gorm:"unique;not null;type:varchar(100)"`
+	Description string     `json:"description" gorm:"type:text"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty" gorm:"type:uuid;index"`
+	Parent      *Group     `json:"-" gorm:"foreignKey:ParentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+
+	AuditInfo
+}
+
+func (g *Group) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+func (Group) TableName() string {
+	return "groups"
+}
+
+type GroupMember struct {
+	GroupID   uuid.UUID `json:"group_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Group     Group     `json:"-" gorm:"foreignKey:GroupID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User      User      `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (GroupMember) TableName() string {
+	return "group_members"
+}
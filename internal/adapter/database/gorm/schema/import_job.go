@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ImportJob struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid;index"`
+	Format          string     `json:"format" gorm:"type:varchar(8);not null"`
+	DryRun          bool       `json:"dry_run" gorm:"default:false"`
+	SendInvitations bool       `json:"send_invitations" gorm:"default:false"`
+	Status          string     `json:"status" gorm:"type:varchar(16);not null;index"`
+	Total           int        `json:"total" gorm:"not null;default:0"`
+	Processed       int        `json:"processed" gorm:"not null;default:0"`
+	Succeeded       int        `json:"succeeded" gorm:"not null;default:0"`
+	Failed          int        `json:"failed" gorm:"not null;default:0"`
+	Error           string     `json:"error,omitempty" gorm:"type:text"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+
+	AuditInfo
+}
+
+func (j *ImportJob) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return nil
+}
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+type ImportJobRow struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	ImportJobID    uuid.UUID  `json:"import_job_id" gorm:"type:uuid;not null;index:idx_import_job_rows_job_row,priority:1"`
+	Row            int        `json:"row" gorm:"not null;index:idx_import_job_rows_job_row,priority:2"`
+	Email          string     `json:"email" gorm:"type:varchar(255)"`
+	Name           string     `json:"name" gorm:"type:varchar(255)"`
+	Status         string     `json:"status" gorm:"type:varchar(16);not null"`
+	UserID         *uuid.UUID `json:"user_id,omitempty" gorm:"type:uuid"`
+	InvitationSent bool       `json:"invitation_sent" gorm:"default:false"`
+	Error          string     `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	ImportJob      ImportJob  `json:"-" gorm:"foreignKey:ImportJobID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (r *ImportJobRow) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+func (ImportJobRow) TableName() string {
+	return "import_job_rows"
+}
@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Invitation struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	TokenHash  string     `json:"-" gorm:"unique;not null;type:varchar(64)"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	User       User       `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	AuditInfo
+}
+
+func (i *Invitation) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+func (Invitation) TableName() string {
+	return "invitations"
+}
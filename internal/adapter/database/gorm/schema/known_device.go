@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type KnownDevice struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	Fingerprint string    `json:"fingerprint" gorm:"type:varchar(64);not null"`
+	IPAddress   string    `json:"ip_address" gorm:"type:varchar(45);not null"`
+	UserAgent   string    `json:"user_agent" gorm:"type:text"`
+	Location    string    `json:"location" gorm:"type:varchar(255)"`
+	LastSeenAt  time.Time `json:"last_seen_at" gorm:"not null"`
+	User        User      `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	AuditInfo
+}
+
+func (d *KnownDevice) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+func (KnownDevice) TableName() string {
+	return "known_devices"
+}
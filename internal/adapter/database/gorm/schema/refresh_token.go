@@ -8,15 +8,15 @@ import (
 )
 
 type RefreshToken struct {
-	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key"`
-	UserID    uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;index"`
-	Token     string         `json:"token" gorm:"unique;not null"`
-	ExpiresAt time.Time      `json:"expires_at" gorm:"not null"`
-	IsRevoked bool           `json:"is_revoked" gorm:"default:false"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
-	User      User           `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ID                uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	UserID            uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	Token             string    `json:"token" gorm:"unique;not null"`
+	ExpiresAt         time.Time `json:"expires_at" gorm:"not null"`
+	IsRevoked         bool      `json:"is_revoked" gorm:"default:false"`
+	IPAddress         string    `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent         string    `json:"user_agent" gorm:"type:text"`
+	ClientFingerprint string    `json:"client_fingerprint" gorm:"type:varchar(64)"`
+	User              User      `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 
 	AuditInfo
 }
@@ -1,7 +1,10 @@
 package schema
 
 import (
+	"time"
+
 	"go-gin-hexagonal/internal/adapter/security"
+	"go-gin-hexagonal/internal/domain/entity"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -13,7 +16,27 @@ type User struct {
 	Username string    `json:"username" gorm:"unique;not null;type:varchar(50)"`
 	Password string    `json:"password" gorm:"not null;type:varchar(255)"`
 	Name     string    `json:"name" gorm:"not null;type:varchar(100)"`
-	IsActive bool      `json:"is_active" gorm:"default:false"`
+	Role     string    `json:"role" gorm:"not null;type:varchar(20);default:'user'"`
+
+	UsernameChangedAt *time.Time `json:"username_changed_at,omitempty"`
+
+	AvatarKey  string                `json:"avatar_key" gorm:"type:varchar(255)"`
+	Attributes entity.UserAttributes `json:"attributes" gorm:"type:jsonb;not null;default:'{}';index:idx_users_attributes,type:gin"`
+
+	Status          string     `json:"status" gorm:"not null;type:user_status;default:'pending_verification';index"`
+	StatusReason    string     `json:"status_reason" gorm:"type:text"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	SuspendedUntil  *time.Time `json:"suspended_until,omitempty"`
+
+	PasswordChangedAt      *time.Time `json:"password_changed_at,omitempty"`
+	MustChangePassword     bool       `json:"must_change_password" gorm:"default:false"`
+	PasswordReminderSentAt *time.Time `json:"password_reminder_sent_at,omitempty"`
+	FirstLoginPending      bool       `json:"first_login_pending" gorm:"not null;default:false"`
+
+	ApprovalStatus    string     `json:"approval_status" gorm:"type:varchar(20);index"`
+	ApprovalReason    string     `json:"approval_reason" gorm:"type:text"`
+	ApprovalDecidedBy *uuid.UUID `json:"approval_decided_by,omitempty" gorm:"type:uuid"`
+	ApprovalDecidedAt *time.Time `json:"approval_decided_at,omitempty"`
 
 	AuditInfo
 }
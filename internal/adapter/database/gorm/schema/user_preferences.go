@@ -0,0 +1,24 @@
+package schema
+
+import (
+	"time"
+
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type UserPreferences struct {
+	UserID             uuid.UUID                   `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Locale             string                      `json:"locale" gorm:"type:varchar(16);not null"`
+	Timezone           string                      `json:"timezone" gorm:"type:varchar(64);not null"`
+	DateFormat         string                      `json:"date_format" gorm:"type:varchar(16);not null"`
+	EmailNotifications entity.NotificationSettings `json:"email_notifications" gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt          time.Time                   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time                   `json:"updated_at" gorm:"autoUpdateTime"`
+	User               User                        `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (UserPreferences) TableName() string {
+	return "user_preferences"
+}
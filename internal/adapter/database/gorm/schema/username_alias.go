@@ -0,0 +1,19 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UsernameAlias struct {
+	Username  string    `json:"username" gorm:"type:varchar(50);primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	User      User      `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (UsernameAlias) TableName() string {
+	return "username_aliases"
+}
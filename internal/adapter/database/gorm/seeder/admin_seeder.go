@@ -0,0 +1,39 @@
+package seeder
+
+import (
+	"go-gin-hexagonal/internal/adapter/database/gorm/schema"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// AdminSeeder creates the admin account from the seed configuration. There is
+// no default password, and the seeded one has to be changed at first sign-in.
+func AdminSeeder(db *gorm.DB, seedConfig config.SeedConfig) error {
+	if seedConfig.AdminPassword == "" {
+		return errors.ErrSeedAdminPasswordMissing
+	}
+
+	admin := schema.User{
+		Name:               "Admin",
+		Email:              seedConfig.AdminEmail,
+		Username:           "admin",
+		Password:           seedConfig.AdminPassword,
+		Role:               entity.RoleAdmin,
+		Status:             entity.UserStatusActive,
+		MustChangePassword: true,
+	}
+
+	isData := db.Find(&schema.User{}, "email = ? OR username = ?", admin.Email, admin.Username).RowsAffected
+	if isData == 0 {
+		if err := db.Create(&admin).Error; err != nil {
+			log.Printf("error seeding admin: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
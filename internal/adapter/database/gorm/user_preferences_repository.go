@@ -0,0 +1,52 @@
+package gorm
+
+import (
+	"context"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserPreferencesRepository works on the db directly since preferences are
+// keyed by user and are never soft deleted.
+type UserPreferencesRepository struct {
+	db *gorm.DB
+}
+
+func NewUserPreferencesRepository(db *gorm.DB) repositories.UserPreferencesRepository {
+	return &UserPreferencesRepository{db: db}
+}
+
+func (r *UserPreferencesRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.UserPreferences, error) {
+	var preferences entity.UserPreferences
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Take(&preferences).Error; err != nil {
+		return nil, err
+	}
+	return &preferences, nil
+}
+
+func (r *UserPreferencesRepository) FindByEmail(ctx context.Context, email string) (*entity.UserPreferences, error) {
+	var preferences entity.UserPreferences
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN users ON users.id = user_preferences.user_id").
+		Where("users.email = ? AND users.is_deleted = ?", email, false).
+		Take(&preferences).Error; err != nil {
+		return nil, err
+	}
+	return &preferences, nil
+}
+
+func (r *UserPreferencesRepository) Save(ctx context.Context, preferences *entity.UserPreferences) (*entity.UserPreferences, error) {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"locale", "timezone", "date_format", "email_notifications", "updated_at"}),
+	}).Create(preferences).Error
+	if err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
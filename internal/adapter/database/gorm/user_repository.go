@@ -2,12 +2,18 @@ package gorm
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
 
 	"go-gin-hexagonal/internal/domain/entity"
 	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/pkg/errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -19,8 +25,136 @@ func NewUserRepository(db *gorm.DB, baseRepo repositories.BaseRepository[entity.
 	return &UserRepository{db: db, baseRepo: baseRepo}
 }
 
-func (r *UserRepository) FindAll(ctx context.Context, limit, offset int, search string) ([]*entity.User, int64, error) {
-	return r.baseRepo.FindAll(ctx, limit, offset, "username LIKE ? OR email LIKE ?", "%"+search+"%", "%"+search+"%")
+// userSortColumns whitelists the fields users can be sorted by, mapped to
+// their columns, so sort input never reaches the query directly.
+var userSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"email":      "email",
+	"username":   "username",
+	"name":       "name",
+	"status":     "status",
+}
+
+// userSearchDocument is the full text document users are searched by. Names
+// and handles are not prose, so the simple configuration skips stemming, and
+// splitting the email lets its local part and domain match on their own. The
+// search migration indexes this exact expression, so keep the two in sync.
+const userSearchDocument = "to_tsvector('simple', name || ' ' || username || ' ' || regexp_replace(email, '[@.]', ' ', 'g'))"
+
+// userSearchCondition matches users by full text, by substring, or, to
+// tolerate typos, by trigram similarity to a word in name, username or email.
+// The trigram indexes serve both ILIKE and <%.
+const userSearchCondition = "(" + userSearchDocument + " @@ websearch_to_tsquery('simple', @term)" +
+	" OR name ILIKE @pattern OR username ILIKE @pattern OR email ILIKE @pattern" +
+	" OR @term <% name OR @term <% username OR @term <% email)"
+
+// userSearchRank scores how well a user matches the search term.
+const userSearchRank = "ts_rank(" + userSearchDocument + ", websearch_to_tsquery('simple', @term))" +
+	" + greatest(word_similarity(@term, name), word_similarity(@term, username), word_similarity(@term, email))"
+
+// likeEscaper escapes the ILIKE wildcards in search terms.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// FindAll returns the matching users, newest first unless sort says otherwise.
+// Searches without an explicit sort are ordered by relevance instead.
+func (r *UserRepository) FindAll(ctx context.Context, filter *repositories.UserFilter, sort *repositories.SortOption, limit, offset int) ([]*entity.User, int64, error) {
+	var users []*entity.User
+	var count int64
+
+	column, desc, err := userSortColumn(sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q := applyUserFilter(r.db.WithContext(ctx).Model(&entity.User{}), filter)
+	if !filter.IncludeDeleted {
+		q = notDeleted(q)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// id breaks ties so pages stay stable when the sort column repeats.
+	if filter.Search != "" && (sort == nil || sort.Field == "") {
+		q = q.Order(clause.OrderBy{Expression: clause.NamedExpr{
+			SQL:  userSearchRank + " DESC, id DESC",
+			Vars: []any{sql.Named("term", filter.Search)},
+		}})
+	} else {
+		q = q.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc}).
+			Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc})
+	}
+	if err := q.Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, count, nil
+}
+
+// FindPage is the keyset paginated variant of FindAll. Relevance cannot serve
+// as a cursor, so searches keep the default order here.
+func (r *UserRepository) FindPage(ctx context.Context, filter *repositories.UserFilter, sort *repositories.SortOption, cursor string, limit int) (*repositories.CursorPage[entity.User], error) {
+	column, desc, err := userSortColumn(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &repositories.CursorQuery{Cursor: cursor, Limit: limit, Column: column, Desc: desc, IncludeDeleted: filter.IncludeDeleted}
+	return r.baseRepo.FindPage(ctx, page, applyUserFilter(r.db, filter))
+}
+
+func userSortColumn(sort *repositories.SortOption) (string, bool, error) {
+	if sort == nil || sort.Field == "" {
+		return "created_at", true, nil
+	}
+
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return "", false, errors.ErrInvalidSortField
+	}
+	return column, sort.Desc, nil
+}
+
+func applyUserFilter(q *gorm.DB, filter *repositories.UserFilter) *gorm.DB {
+	if filter.Search != "" {
+		q = q.Where(userSearchCondition,
+			sql.Named("term", filter.Search),
+			sql.Named("pattern", "%"+likeEscaper.Replace(filter.Search)+"%"),
+		)
+	}
+	if filter.Email != "" {
+		q = q.Where("email = ?", filter.Email)
+	}
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if filter.IsActive != nil {
+		if *filter.IsActive {
+			q = q.Where("status = ?", entity.UserStatusActive)
+		} else {
+			q = q.Where("status <> ?", entity.UserStatusActive)
+		}
+	}
+	if filter.CreatedFrom != nil {
+		q = q.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		q = q.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		q = q.Where("updated_at >= ?", *filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		q = q.Where("updated_at < ?", *filter.UpdatedTo)
+	}
+	if len(filter.Attributes) > 0 {
+		// Containment is served by the GIN index on attributes. The values
+		// are already typed, so marshalling cannot fail.
+		contains, _ := json.Marshal(filter.Attributes)
+		q = q.Where("attributes @> ?::jsonb", string(contains))
+	}
+	return q
 }
 
 func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
@@ -48,15 +182,110 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity
 }
 
 func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
-	return r.baseRepo.FindFirst(ctx, "username = ?", username)
+	user, err := r.baseRepo.FindFirst(ctx, "username = ?", username)
+	if err == nil {
+		return user, nil
+	}
+	return r.baseRepo.FindFirst(ctx, "id = (?)", r.activeAlias(username).Select("user_id"))
+}
+
+func (r *UserRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	return r.baseRepo.FindDeletedByID(ctx, id)
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.baseRepo.Restore(ctx, id)
 }
 
+func (r *UserRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]*entity.User, error) {
+	return r.baseRepo.Purge(ctx, deletedBefore)
+}
+
+// ExistsByEmail also counts soft deleted users, whose email stays reserved
+// until they are purged.
 func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) bool {
-	isExist, _ := r.baseRepo.WhereExisting(ctx, "email = ?", email)
-	return isExist
+	return r.existsIncludingDeleted(ctx, "email = ?", email)
 }
 
+// ExistsByUsername also counts soft deleted users, like ExistsByEmail, and
+// usernames still held as aliases.
 func (r *UserRepository) ExistsByUsername(ctx context.Context, username string) bool {
-	isExist, _ := r.baseRepo.WhereExisting(ctx, "username = ?", username)
-	return isExist
+	if r.existsIncludingDeleted(ctx, "username = ?", username) {
+		return true
+	}
+
+	var count int64
+	r.activeAlias(username).WithContext(ctx).Limit(1).Count(&count)
+	return count > 0
+}
+
+func (r *UserRepository) activeAlias(username string) *gorm.DB {
+	return r.db.Model(&entity.UsernameAlias{}).Where("username = ? AND expires_at > ?", username, time.Now())
+}
+
+func (r *UserRepository) SaveUsernameAlias(ctx context.Context, alias *entity.UsernameAlias) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "username"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "expires_at", "created_at"}),
+	}).Create(alias).Error
+}
+
+func (r *UserRepository) DeleteUsernameAlias(ctx context.Context, username string) error {
+	return r.db.WithContext(ctx).Delete(&entity.UsernameAlias{}, "username = ?", username).Error
+}
+
+func (r *UserRepository) DeleteExpiredUsernameAliases(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.UsernameAlias{}, "expires_at <= ?", now)
+	return result.RowsAffected, result.Error
+}
+
+func (r *UserRepository) existsIncludingDeleted(ctx context.Context, query string, args ...any) bool {
+	var count int64
+	r.db.WithContext(ctx).Model(&entity.User{}).Where(query, args...).Limit(1).Count(&count)
+	return count > 0
+}
+
+func (r *UserRepository) ExistsByAttribute(ctx context.Context, key string, value any, excludeID uuid.UUID) (bool, error) {
+	contains, err := json.Marshal(map[string]any{key: value})
+	if err != nil {
+		return false, err
+	}
+	return r.baseRepo.WhereExisting(ctx, "attributes @> ?::jsonb AND id <> ?", string(contains), excludeID)
+}
+
+func (r *UserRepository) HasDuplicateAttribute(ctx context.Context, key string) (bool, error) {
+	var exists bool
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT EXISTS (SELECT 1 FROM users WHERE is_deleted = false AND attributes -> @key IS NOT NULL GROUP BY attributes -> @key HAVING count(*) > 1)",
+		sql.Named("key", key),
+	).Scan(&exists).Error
+	return exists, err
+}
+
+// FindPasswordExpiring returns active users whose password was last changed
+// before changedBefore and who have not been reminded about it yet.
+func (r *UserRepository) FindPasswordExpiring(ctx context.Context, changedBefore time.Time) ([]*entity.User, error) {
+	return r.baseRepo.Where(ctx,
+		"status = 'active' AND password <> '' AND COALESCE(password_changed_at, created_at) < ? AND (password_reminder_sent_at IS NULL OR password_reminder_sent_at < COALESCE(password_changed_at, created_at))",
+		changedBefore,
+	)
+}
+
+func (r *UserRepository) FindExpiredSuspensions(ctx context.Context, now time.Time) ([]*entity.User, error) {
+	return r.baseRepo.Where(ctx, "status = 'suspended' AND suspended_until IS NOT NULL AND suspended_until <= ?", now)
+}
+
+func (r *UserRepository) FindByApprovalStatus(ctx context.Context, status string, limit, offset int) ([]*entity.User, int64, error) {
+	q := notDeleted(r.db.WithContext(ctx).Model(&entity.User{})).Where("approval_status = ?", status)
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []*entity.User
+	if err := q.Order("created_at asc, id asc").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
 }
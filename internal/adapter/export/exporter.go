@@ -0,0 +1,102 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/errors"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+type Exporter struct{}
+
+func NewExporter() ports.Exporter {
+	return &Exporter{}
+}
+
+func (e *Exporter) NewWriter(w io.Writer, format string, header []string) (ports.RecordWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, header)
+	case FormatNDJSON:
+		return newNDJSONWriter(w, header), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, header)
+	default:
+		return nil, errors.ErrInvalidExportFormat
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, header []string) (*csvWriter, error) {
+	writer := &csvWriter{writer: csv.NewWriter(w)}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) Write(record []string) error {
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvWriter) Close() error {
+	return w.Flush()
+}
+
+// ndjsonWriter emits one JSON object per line, keyed by the header and with
+// the keys kept in header order.
+type ndjsonWriter struct {
+	writer *bufio.Writer
+	keys   [][]byte
+}
+
+func newNDJSONWriter(w io.Writer, header []string) *ndjsonWriter {
+	keys := make([][]byte, len(header))
+	for i, column := range header {
+		keys[i], _ = json.Marshal(column)
+	}
+	return &ndjsonWriter{writer: bufio.NewWriter(w), keys: keys}
+}
+
+func (w *ndjsonWriter) Write(record []string) error {
+	w.writer.WriteByte('{')
+	for i, value := range record {
+		if i > 0 {
+			w.writer.WriteByte(',')
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		w.writer.Write(w.keys[i])
+		w.writer.WriteByte(':')
+		w.writer.Write(encoded)
+	}
+	w.writer.WriteByte('}')
+	return w.writer.WriteByte('\n')
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.writer.Flush()
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.Flush()
+}
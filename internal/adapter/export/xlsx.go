@@ -0,0 +1,111 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// The workbook is the smallest SpreadsheetML package Excel and LibreOffice
+// accept: one sheet of inline strings, so rows can be streamed without a
+// shared string table.
+var xlsxParts = []struct{ name, body string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+const (
+	xlsxSheetPath   = "xl/worksheets/sheet1.xml"
+	xlsxSheetHeader = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+}
+
+func newXLSXWriter(w io.Writer, header []string) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	// The sheet is the last entry so it can stay open while rows arrive.
+	sheet, err := archive.Create(xlsxSheetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &xlsxWriter{archive: archive, sheet: bufio.NewWriter(sheet)}
+	writer.sheet.WriteString(xlsxSheetHeader)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *xlsxWriter) Write(record []string) error {
+	w.row++
+	row := strconv.Itoa(w.row)
+
+	w.sheet.WriteString(`<row r="` + row + `">`)
+	for i, value := range record {
+		if value == "" {
+			continue
+		}
+		w.sheet.WriteString(`<c r="` + xlsxColumn(i) + row + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(w.sheet, []byte(value)); err != nil {
+			return err
+		}
+		w.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (w *xlsxWriter) Flush() error {
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.archive.Flush()
+}
+
+func (w *xlsxWriter) Close() error {
+	w.sheet.WriteString(xlsxSheetFooter)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.archive.Close()
+}
+
+// xlsxColumn converts a zero-based column index to its letter name (A, B, ..., AA).
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
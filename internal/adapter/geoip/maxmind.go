@@ -0,0 +1,63 @@
+package geoip
+
+import (
+	"net"
+	"strings"
+
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+type MaxMindLocator struct {
+	reader *geoip2.Reader
+}
+
+// NewMaxMindLocator opens a local GeoLite2/GeoIP2 City database. When no
+// database path is configured, lookups resolve to an unknown location.
+func NewMaxMindLocator(cfg config.GeoIPConfig) (ports.GeoLocator, error) {
+	if cfg.DatabasePath == "" {
+		return &MaxMindLocator{}, nil
+	}
+
+	reader, err := geoip2.Open(cfg.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MaxMindLocator{reader: reader}, nil
+}
+
+func (l *MaxMindLocator) Locate(ip string) string {
+	if l.reader == nil {
+		return ""
+	}
+
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return ""
+	}
+
+	record, err := l.reader.City(parsedIP)
+	if err != nil {
+		return ""
+	}
+
+	var parts []string
+	if city := record.City.Names["en"]; city != "" {
+		parts = append(parts, city)
+	}
+	if country := record.Country.Names["en"]; country != "" {
+		parts = append(parts, country)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (l *MaxMindLocator) Close() error {
+	if l.reader == nil {
+		return nil
+	}
+	return l.reader.Close()
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AttributeHandler struct {
+	attributeService services.AttributeService
+}
+
+func NewAttributeHandler(attributeService services.AttributeService) *AttributeHandler {
+	return &AttributeHandler{
+		attributeService: attributeService,
+	}
+}
+
+func (h *AttributeHandler) ListDefinitions(c *gin.Context) {
+	result, err := h.attributeService.ListDefinitions(c.Request.Context())
+	if err != nil {
+		response.Error(c, message.FAILED_GET_ATTRIBUTE_DEFINITIONS, err.Error(), 500)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_GET_ATTRIBUTE_DEFINITIONS, mapper.MapAttributeDefinitionInfosToDTO(result), 200)
+}
+
+func (h *AttributeHandler) CreateDefinition(c *gin.Context) {
+	var req dto.CreateAttributeDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.attributeService.CreateDefinition(c.Request.Context(), mapper.MapCreateAttributeDefinitionRequestToService(&req))
+	if err != nil {
+		h.definitionError(c, message.FAILED_CREATE_ATTRIBUTE_DEFINITION, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_CREATE_ATTRIBUTE_DEFINITION, mapper.MapAttributeDefinitionInfoToDTO(result), 201)
+}
+
+func (h *AttributeHandler) UpdateDefinition(c *gin.Context) {
+	definitionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.UpdateAttributeDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.attributeService.UpdateDefinition(c.Request.Context(), definitionID, mapper.MapUpdateAttributeDefinitionRequestToService(&req))
+	if err != nil {
+		h.definitionError(c, message.FAILED_UPDATE_ATTRIBUTE_DEFINITION, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_UPDATE_ATTRIBUTE_DEFINITION, mapper.MapAttributeDefinitionInfoToDTO(result), 200)
+}
+
+func (h *AttributeHandler) DeleteDefinition(c *gin.Context) {
+	definitionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	if err := h.attributeService.DeleteDefinition(c.Request.Context(), definitionID); err != nil {
+		h.definitionError(c, message.FAILED_DELETE_ATTRIBUTE_DEFINITION, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_DELETE_ATTRIBUTE_DEFINITION, nil, 200)
+}
+
+func (h *AttributeHandler) definitionError(c *gin.Context, msg string, err error) {
+	switch err {
+	case errors.ErrInvalidAttributeKey, errors.ErrInvalidAttributeType, errors.ErrInvalidAttributePattern:
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+	case errors.ErrAttributeDefinitionNotFound:
+		response.Error(c, message.FAILED_ATTRIBUTE_NOT_FOUND, err.Error(), 404)
+	case errors.ErrAttributeDefinitionExists:
+		response.Error(c, message.FAILED_ATTRIBUTE_ALREADY_EXISTS, err.Error(), 409)
+	case errors.ErrAttributeHasDuplicates:
+		response.Error(c, message.FAILED_ATTRIBUTE_NOT_UNIQUE, err.Error(), 409)
+	default:
+		response.Error(c, msg, err.Error(), 500)
+	}
+}
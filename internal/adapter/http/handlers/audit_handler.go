@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/ports/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+func (h *AuditHandler) ListAuditEvents(c *gin.Context) {
+	var req dto.ListAuditEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	mapReq := mapper.MapListAuditEventsRequestToService(&req)
+
+	result, err := h.auditService.ListEvents(c.Request.Context(), mapReq)
+	if err != nil {
+		response.Error(c, message.FAILED_GET_AUDIT_EVENTS, err.Error(), 500)
+		return
+	}
+
+	meta := &response.Meta{
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}
+
+	response.SuccessWithMeta(c, message.SUCCESS_GET_AUDIT_EVENTS, mapper.MapAuditEventInfosToDTO(result.Datas), meta)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AuthHandler) sameSiteMode() http.SameSite {
+	switch strings.ToLower(h.cookieConfig.SameSite) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+func (h *AuthHandler) setCookie(c *gin.Context, name, value, path string, expiresAt time.Time) {
+	maxAge := int(time.Until(expiresAt).Seconds())
+	if value == "" {
+		maxAge = -1
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   h.cookieConfig.Domain,
+		Expires:  expiresAt,
+		MaxAge:   maxAge,
+		Secure:   h.cookieConfig.Secure,
+		HttpOnly: true,
+		SameSite: h.sameSiteMode(),
+	})
+}
+
+// setTokenCookies stores both tokens as HttpOnly cookies. The refresh token
+// cookie is scoped to the auth endpoints, which need it to refresh and log
+// out, so it is not sent with every request.
+func (h *AuthHandler) setTokenCookies(c *gin.Context, accessToken string, accessExpiresAt time.Time, refreshToken string, refreshExpiresAt time.Time) {
+	h.setCookie(c, h.cookieConfig.AccessTokenName, accessToken, "/", accessExpiresAt)
+	h.setCookie(c, h.cookieConfig.RefreshTokenName, refreshToken, h.cookieConfig.RefreshTokenPath, refreshExpiresAt)
+}
+
+// setRestrictedTokenCookie stores a password change token and drops any
+// refresh token cookie, which is not valid for such sessions.
+func (h *AuthHandler) setRestrictedTokenCookie(c *gin.Context, accessToken string, accessExpiresAt time.Time) {
+	h.setCookie(c, h.cookieConfig.AccessTokenName, accessToken, "/", accessExpiresAt)
+	h.setCookie(c, h.cookieConfig.RefreshTokenName, "", h.cookieConfig.RefreshTokenPath, time.Unix(0, 0))
+}
+
+func (h *AuthHandler) clearTokenCookies(c *gin.Context) {
+	h.setCookie(c, h.cookieConfig.AccessTokenName, "", "/", time.Unix(0, 0))
+	h.setCookie(c, h.cookieConfig.RefreshTokenName, "", h.cookieConfig.RefreshTokenPath, time.Unix(0, 0))
+}
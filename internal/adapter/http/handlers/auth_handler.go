@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"io"
+
 	response "go-gin-hexagonal/internal/adapter/http"
 	"go-gin-hexagonal/internal/adapter/http/message"
 	"go-gin-hexagonal/internal/application/dto"
 	"go-gin-hexagonal/internal/application/mapper"
 	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
 	"go-gin-hexagonal/pkg/errors"
 
 	"github.com/gin-gonic/gin"
@@ -13,12 +16,14 @@ import (
 )
 
 type AuthHandler struct {
-	authService services.AuthService
+	authService  services.AuthService
+	cookieConfig config.CookieConfig
 }
 
-func NewAuthHandler(authService services.AuthService) *AuthHandler {
+func NewAuthHandler(authService services.AuthService, cookieConfig config.CookieConfig) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:  authService,
+		cookieConfig: cookieConfig,
 	}
 }
 
@@ -29,7 +34,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.Login(c.Request.Context(), (*services.LoginRequest)(&req))
+	mapReq := mapper.MapLoginRequestDTOToService(&req)
+	mapReq.Client = clientInfo(c)
+
+	result, err := h.authService.Login(c.Request.Context(), mapReq)
 	if err != nil {
 		switch err {
 		case errors.ErrPasswordMismatch:
@@ -38,17 +46,46 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
 		case errors.ErrUserNotVerified:
 			response.Error(c, message.FAILED_FORBIDDEN, err.Error(), 403)
+		case errors.ErrInvitationPending:
+			response.Error(c, message.FAILED_INVITATION_PENDING, err.Error(), 403)
+		case errors.ErrApprovalPending:
+			response.Error(c, message.FAILED_APPROVAL_PENDING, err.Error(), 403)
+		case errors.ErrRegistrationRejected:
+			response.Error(c, message.FAILED_REGISTRATION_REJECTED, err.Error(), 403)
+		case errors.ErrAccountSuspended:
+			response.Error(c, message.FAILED_ACCOUNT_SUSPENDED, err.Error(), 403)
+		case errors.ErrAccountDeactivated:
+			response.Error(c, message.FAILED_ACCOUNT_DEACTIVATED, err.Error(), 403)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
 		return
 	}
 
+	if h.cookieConfig.Enabled {
+		if result.PasswordChangeRequired {
+			h.setRestrictedTokenCookie(c, result.AccessToken, result.AccessTokenExpiresAt)
+			response.Success(c, message.SUCCESS_LOGIN, &dto.LoginResponse{PasswordChangeRequired: true}, 200)
+			return
+		}
+
+		h.setTokenCookies(c, result.AccessToken, result.AccessTokenExpiresAt, result.RefreshToken, result.RefreshTokenExpiresAt)
+		response.Success(c, message.SUCCESS_LOGIN, nil, 200)
+		return
+	}
+
 	mapResult := mapper.MapLoginResponseServiceToDTO(result)
 
 	response.Success(c, message.SUCCESS_LOGIN, mapResult, 200)
 }
 
+func clientInfo(c *gin.Context) services.ClientInfo {
+	return services.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -63,6 +100,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		switch err {
 		case errors.ErrUserAlreadyExists:
 			response.Error(c, message.FAILED_REGISTER_USER, err.Error(), 409)
+		case errors.ErrUsernameReserved:
+			response.Error(c, message.FAILED_USERNAME_RESERVED, err.Error(), 409)
+		case errors.ErrEmailDomainNotAllowed, errors.ErrEmailDomainBlocked, errors.ErrDisposableEmail:
+			response.Error(c, message.FAILED_EMAIL_DOMAIN_REJECTED, err.Error(), 422)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
@@ -74,45 +115,94 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req dto.RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
 		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
 		return
 	}
 
-	result, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
+	if req.RefreshToken == "" && h.cookieConfig.Enabled {
+		req.RefreshToken, _ = c.Cookie(h.cookieConfig.RefreshTokenName)
+	}
+
+	if req.RefreshToken == "" {
+		response.Error(c, message.FAILED_TOKEN_NOT_FOUND, errors.ErrTokenNotFound.Error(), 401)
+		return
+	}
+
+	mapReq := mapper.MapRefreshTokenRequestDTOToService(&req)
+	mapReq.Client = clientInfo(c)
+
+	result, err := h.authService.RefreshToken(c.Request.Context(), mapReq)
 	if err != nil {
 		switch err {
 		case errors.ErrTokenInvalid:
 			response.Error(c, message.FAILED_TOKEN_INVALID, err.Error(), 401)
 		case errors.ErrUserNotFound:
 			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		case errors.ErrAccountSuspended:
+			response.Error(c, message.FAILED_ACCOUNT_SUSPENDED, err.Error(), 403)
+		case errors.ErrAccountDeactivated, errors.ErrRegistrationRejected:
+			response.Error(c, message.FAILED_ACCOUNT_DEACTIVATED, err.Error(), 403)
+		case errors.ErrUserNotVerified, errors.ErrApprovalPending:
+			response.Error(c, message.FAILED_FORBIDDEN, err.Error(), 403)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
 		return
 	}
 
+	if h.cookieConfig.Enabled {
+		if result.PasswordChangeRequired {
+			h.setRestrictedTokenCookie(c, result.AccessToken, result.AccessTokenExpiresAt)
+			response.Success(c, message.SUCCESS_REFRESH_TOKEN, &dto.RefreshTokenResponse{PasswordChangeRequired: true}, 200)
+			return
+		}
+
+		h.setTokenCookies(c, result.AccessToken, result.AccessTokenExpiresAt, result.RefreshToken, result.RefreshTokenExpiresAt)
+		response.Success(c, message.SUCCESS_REFRESH_TOKEN, nil, 200)
+		return
+	}
+
 	mapResult := mapper.MapRefreshTokenResponseServiceToDTO(result)
 
 	response.Success(c, message.SUCCESS_REFRESH_TOKEN, mapResult, 200)
 }
 
+// Logout ends the sessions of the authenticated user or, when the access token
+// is missing or expired, of the user the refresh token belongs to.
 func (h *AuthHandler) Logout(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		response.Error(c, message.FAILED_UNAUTHORIZED, errors.ErrInvalidCredentials.Error(), 401)
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
 		return
 	}
 
-	userUUID, ok := userID.(uuid.UUID)
-	if !ok {
-		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+	if req.RefreshToken == "" && h.cookieConfig.Enabled {
+		req.RefreshToken, _ = c.Cookie(h.cookieConfig.RefreshTokenName)
+	}
+
+	var err error
+	if userID, exists := c.Get("user_id"); exists {
+		err = h.authService.Logout(c.Request.Context(), userID.(uuid.UUID))
+	} else if req.RefreshToken != "" {
+		err = h.authService.LogoutWithRefreshToken(c.Request.Context(), req.RefreshToken)
+	} else {
+		response.Error(c, message.FAILED_UNAUTHORIZED, errors.ErrInvalidCredentials.Error(), 401)
 		return
 	}
 
-	err := h.authService.Logout(c.Request.Context(), userUUID)
+	// Cookies that no longer identify a session are dropped either way.
+	if h.cookieConfig.Enabled {
+		h.clearTokenCookies(c)
+	}
+
 	if err != nil {
-		response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		switch err {
+		case errors.ErrTokenInvalid:
+			response.Error(c, message.FAILED_TOKEN_INVALID, err.Error(), 401)
+		default:
+			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		}
 		return
 	}
 
@@ -131,6 +221,10 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 		switch err {
 		case errors.ErrUserNotFound:
 			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		case errors.ErrInvitationPending:
+			response.Error(c, message.FAILED_INVITATION_PENDING, err.Error(), 409)
+		case errors.ErrRegistrationRejected:
+			response.Error(c, message.FAILED_REGISTRATION_REJECTED, err.Error(), 403)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
@@ -206,3 +300,63 @@ func (h *AuthHandler) SendResetPassword(c *gin.Context) {
 
 	response.Success(c, message.SUCCESS_SENT_RESET_PASSWORD, nil, 200)
 }
+
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	mapReq := mapper.MapAcceptInvitationRequestDTOToService(&req)
+
+	err := h.authService.AcceptInvitation(c.Request.Context(), mapReq)
+	if err != nil {
+		switch err {
+		case errors.ErrInvitationNotFound:
+			response.Error(c, message.FAILED_INVITATION_NOT_FOUND, err.Error(), 404)
+		case errors.ErrInvitationAlreadyAccepted:
+			response.Error(c, message.FAILED_INVITATION_ALREADY_ACCEPTED, err.Error(), 409)
+		case errors.ErrTokenInvalid:
+			response.Error(c, message.FAILED_TOKEN_INVALID, err.Error(), 401)
+		case errors.ErrTokenExpired:
+			response.Error(c, message.FAILED_TOKEN_EXPIRED, err.Error(), 401)
+		case errors.ErrUserNotFound:
+			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		default:
+			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		}
+		return
+	}
+
+	response.Success(c, message.SUCCESS_ACCEPT_INVITATION, nil, 200)
+}
+
+func (h *AuthHandler) SecureAccount(c *gin.Context) {
+	var req dto.SecureAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	err := h.authService.SecureAccount(c.Request.Context(), req.Token)
+	if err != nil {
+		switch err {
+		case errors.ErrTokenInvalid:
+			response.Error(c, message.FAILED_TOKEN_INVALID, err.Error(), 401)
+		case errors.ErrTokenExpired:
+			response.Error(c, message.FAILED_TOKEN_EXPIRED, err.Error(), 401)
+		case errors.ErrUserNotFound:
+			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		default:
+			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		}
+		return
+	}
+
+	if h.cookieConfig.Enabled {
+		h.clearTokenCookies(c)
+	}
+
+	response.Success(c, message.SUCCESS_SECURE_ACCOUNT, nil, 200)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AvatarHandler struct {
+	avatarService services.AvatarService
+}
+
+func NewAvatarHandler(avatarService services.AvatarService) *AvatarHandler {
+	return &AvatarHandler{
+		avatarService: avatarService,
+	}
+}
+
+func (h *AvatarHandler) UploadAvatar(c *gin.Context) {
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_AVATAR, err.Error(), 400)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.avatarService.UploadAvatar(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), file)
+	if err != nil {
+		switch err {
+		case errors.ErrUserNotFound:
+			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		case errors.ErrAvatarTooLarge:
+			response.Error(c, message.FAILED_INVALID_AVATAR, err.Error(), 413)
+		case errors.ErrUnsupportedAvatarType:
+			response.Error(c, message.FAILED_INVALID_AVATAR, err.Error(), 415)
+		case errors.ErrInvalidAvatarImage:
+			response.Error(c, message.FAILED_INVALID_AVATAR, err.Error(), 422)
+		default:
+			response.Error(c, message.FAILED_UPLOAD_AVATAR, err.Error(), 500)
+		}
+		return
+	}
+
+	response.Success(c, message.SUCCESS_UPLOAD_AVATAR, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *AvatarHandler) DeleteAvatar(c *gin.Context) {
+	result, err := h.avatarService.DeleteAvatar(c.Request.Context(), c.MustGet("user_id").(uuid.UUID))
+	if err != nil {
+		switch err {
+		case errors.ErrUserNotFound:
+			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		case errors.ErrAvatarNotFound:
+			response.Error(c, message.FAILED_AVATAR_NOT_FOUND, err.Error(), 404)
+		default:
+			response.Error(c, message.FAILED_DELETE_AVATAR, err.Error(), 500)
+		}
+		return
+	}
+
+	response.Success(c, message.SUCCESS_DELETE_AVATAR, mapper.MapUserInfoToDTO(result), 200)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag tags the response with the resource version so clients can make
+// their next update conditional with If-Match.
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersions returns the versions an If-Match header lists, or nil when
+// the request is unconditional. The header may list several ETags, any of
+// which matches (RFC 9110, section 13.1.1). ok is false when none of them can
+// match an ETag this API issues, including weak ones, which If-Match never
+// matches.
+func ifMatchVersions(c *gin.Context) (versions []int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	versions = []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if !strings.HasPrefix(tag, `"`) {
+			continue
+		}
+		unquoted, err := strconv.Unquote(tag)
+		if err != nil {
+			continue
+		}
+		parsed, err := strconv.Atoi(unquoted)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, parsed)
+	}
+	return versions, len(versions) > 0
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"ndjson": "application/x-ndjson",
+	"xlsx":   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+type ExportHandler struct {
+	exportService services.ExportService
+}
+
+func NewExportHandler(exportService services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+func (h *ExportHandler) ExportUsers(c *gin.Context) {
+	var req dto.ExportUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+	req.Attributes = c.QueryMap("attributes")
+
+	// Large exports outlive the server's write timeout; the download stays
+	// bounded by the client and the request context instead.
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("user export: cannot clear write deadline: %v", err)
+	}
+
+	filename := fmt.Sprintf("users-%s.%s", time.Now().UTC().Format("20060102-150405"), req.Format)
+	c.Header("Content-Type", exportContentTypes[req.Format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	err := h.exportService.ExportUsers(c.Request.Context(), mapper.MapExportUsersRequestToService(&req), c.Writer)
+	if err == nil {
+		return
+	}
+
+	// Once the body has started the status can no longer change, so the
+	// client is left with a truncated file.
+	if c.Writer.Written() {
+		log.Printf("user export aborted: %v", err)
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	if _, ok := err.(*errors.AttributeError); ok {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+	switch err {
+	case errors.ErrInvalidSortField, errors.ErrInvalidExportFormat:
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+	default:
+		response.Error(c, message.FAILED_EXPORT_USERS, err.Error(), 500)
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GroupHandler struct {
+	groupService services.GroupService
+}
+
+func NewGroupHandler(groupService services.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	result, err := h.groupService.ListGroups(c.Request.Context())
+	if err != nil {
+		response.Error(c, message.FAILED_GET_GROUPS, err.Error(), 500)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_GET_GROUPS, mapper.MapGroupInfosToDTO(result), 200)
+}
+
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	result, err := h.groupService.GetGroup(c.Request.Context(), groupID)
+	if err != nil {
+		h.groupError(c, message.FAILED_GET_GROUP, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_GET_GROUP, mapper.MapGroupInfoToDTO(result), 200)
+}
+
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	var req dto.CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.groupService.CreateGroup(c.Request.Context(), mapper.MapCreateGroupRequestToService(&req))
+	if err != nil {
+		h.groupError(c, message.FAILED_CREATE_GROUP, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_CREATE_GROUP, mapper.MapGroupInfoToDTO(result), 201)
+}
+
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.groupService.UpdateGroup(c.Request.Context(), groupID, mapper.MapUpdateGroupRequestToService(&req))
+	if err != nil {
+		h.groupError(c, message.FAILED_UPDATE_GROUP, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_UPDATE_GROUP, mapper.MapGroupInfoToDTO(result), 200)
+}
+
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	if err := h.groupService.DeleteGroup(c.Request.Context(), groupID); err != nil {
+		h.groupError(c, message.FAILED_DELETE_GROUP, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_DELETE_GROUP, nil, 200)
+}
+
+func (h *GroupHandler) ListMembers(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.ListGroupMembersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.groupService.ListMembers(c.Request.Context(), groupID, mapper.MapListGroupMembersRequestToService(&req))
+	if err != nil {
+		h.groupError(c, message.FAILED_GET_GROUP_MEMBERS, err)
+		return
+	}
+
+	meta := &response.Meta{
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}
+
+	response.SuccessWithMeta(c, message.SUCCESS_GET_GROUP_MEMBERS, mapper.MapUserPaginationResponseToDTO(result).Datas, meta)
+}
+
+func (h *GroupHandler) AddMember(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.AddGroupMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	if err := h.groupService.AddMember(c.Request.Context(), groupID, uuid.MustParse(req.UserID)); err != nil {
+		h.groupError(c, message.FAILED_ADD_GROUP_MEMBER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_ADD_GROUP_MEMBER, nil, 201)
+}
+
+func (h *GroupHandler) RemoveMember(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	if err := h.groupService.RemoveMember(c.Request.Context(), groupID, userID); err != nil {
+		h.groupError(c, message.FAILED_REMOVE_GROUP_MEMBER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_REMOVE_GROUP_MEMBER, nil, 200)
+}
+
+func (h *GroupHandler) ListUserGroups(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	h.listUserGroups(c, userID)
+}
+
+func (h *GroupHandler) ListProfileGroups(c *gin.Context) {
+	h.listUserGroups(c, c.MustGet("user_id").(uuid.UUID))
+}
+
+func (h *GroupHandler) listUserGroups(c *gin.Context, userID uuid.UUID) {
+	result, err := h.groupService.ListUserGroups(c.Request.Context(), userID)
+	if err != nil {
+		h.groupError(c, message.FAILED_GET_GROUPS, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_GET_GROUPS, mapper.MapGroupInfosToDTO(result), 200)
+}
+
+func (h *GroupHandler) groupError(c *gin.Context, msg string, err error) {
+	switch err {
+	case errors.ErrInvalidGroupName, errors.ErrInvalidGroupParent:
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+	case errors.ErrGroupNotFound:
+		response.Error(c, message.FAILED_GROUP_NOT_FOUND, err.Error(), 404)
+	case errors.ErrUserNotFound:
+		response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+	case errors.ErrGroupMemberNotFound:
+		response.Error(c, message.FAILED_NOT_GROUP_MEMBER, err.Error(), 404)
+	case errors.ErrGroupExists:
+		response.Error(c, message.FAILED_GROUP_ALREADY_EXISTS, err.Error(), 409)
+	case errors.ErrGroupHasSubgroups:
+		response.Error(c, message.FAILED_GROUP_HAS_SUBGROUPS, err.Error(), 409)
+	case errors.ErrGroupMemberExists:
+		response.Error(c, message.FAILED_GROUP_MEMBER_EXISTS, err.Error(), 409)
+	default:
+		response.Error(c, msg, err.Error(), 500)
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ImportHandler struct {
+	importService services.ImportService
+}
+
+func NewImportHandler(importService services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+func (h *ImportHandler) StartImport(c *gin.Context) {
+	var req dto.StartImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_IMPORT_FILE, err.Error(), 400)
+		return
+	}
+	defer file.Close()
+
+	mapReq := mapper.MapStartImportRequestToService(&req, c.MustGet("user_id").(uuid.UUID), file)
+
+	result, err := h.importService.StartImport(c.Request.Context(), mapReq)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidImportFormat, errors.ErrInvalidImportFile, errors.ErrImportEmpty:
+			response.Error(c, message.FAILED_INVALID_IMPORT_FILE, err.Error(), 400)
+		case errors.ErrImportTooLarge, errors.ErrImportTooManyRows:
+			response.Error(c, message.FAILED_IMPORT_TOO_LARGE, err.Error(), 413)
+		default:
+			response.Error(c, message.FAILED_START_IMPORT, err.Error(), 500)
+		}
+		return
+	}
+
+	response.Success(c, message.SUCCESS_START_IMPORT, mapper.MapImportJobInfoToDTO(result), 202)
+}
+
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.GetImportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.importService.GetImport(c.Request.Context(), jobID, req.Page, req.PageSize)
+	if err != nil {
+		switch err {
+		case errors.ErrImportJobNotFound:
+			response.Error(c, message.FAILED_IMPORT_NOT_FOUND, err.Error(), 404)
+		default:
+			response.Error(c, message.FAILED_GET_IMPORT, err.Error(), 500)
+		}
+		return
+	}
+
+	meta := &response.Meta{
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}
+
+	response.SuccessWithMeta(c, message.SUCCESS_GET_IMPORT, mapper.MapImportJobDetailToDTO(result), meta)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PreferencesHandler struct {
+	preferencesService services.PreferencesService
+}
+
+func NewPreferencesHandler(preferencesService services.PreferencesService) *PreferencesHandler {
+	return &PreferencesHandler{
+		preferencesService: preferencesService,
+	}
+}
+
+func (h *PreferencesHandler) GetPreferences(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	result, err := h.preferencesService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		h.preferencesError(c, message.FAILED_GET_PREFERENCES, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_GET_PREFERENCES, mapper.MapPreferencesInfoToDTO(result), 200)
+}
+
+func (h *PreferencesHandler) UpdatePreferences(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	var req dto.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.preferencesService.UpdatePreferences(c.Request.Context(), userID, mapper.MapUpdatePreferencesRequestToService(&req))
+	if err != nil {
+		h.preferencesError(c, message.FAILED_UPDATE_PREFERENCES, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_UPDATE_PREFERENCES, mapper.MapPreferencesInfoToDTO(result), 200)
+}
+
+func (h *PreferencesHandler) preferencesError(c *gin.Context, msg string, err error) {
+	switch err {
+	case errors.ErrUnsupportedLocale, errors.ErrInvalidTimezone, errors.ErrInvalidDateFormat, errors.ErrUnknownNotificationCategory:
+		response.Error(c, message.FAILED_INVALID_PREFERENCES, err.Error(), 400)
+	case errors.ErrUserNotFound:
+		response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+	default:
+		response.Error(c, msg, err.Error(), 500)
+	}
+}
@@ -1,18 +1,32 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	response "go-gin-hexagonal/internal/adapter/http"
 	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/adapter/http/patch"
 	"go-gin-hexagonal/internal/application/dto"
 	"go-gin-hexagonal/internal/application/mapper"
+	"go-gin-hexagonal/internal/domain/entity"
 	"go-gin-hexagonal/internal/domain/ports/services"
 	"go-gin-hexagonal/pkg/errors"
+	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 )
 
+// patchAttempts bounds how often an unconditional patch is reapplied when a
+// concurrent update changes the user first.
+const patchAttempts = 3
+
 type UserHandler struct {
 	userService services.UserService
 }
@@ -49,12 +63,13 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 	mapResult := mapper.MapUserInfoToDTO(result)
 
+	setETag(c, result.Version)
 	response.Success(c, message.SUCCESS_GET_USER_BY_ID, mapResult, 200)
 }
 
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req dto.CreateUserRequest
-	if err := c.ShouldBindJSON(req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
 		return
 	}
@@ -63,9 +78,16 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	result, err := h.userService.CreateUser(c.Request.Context(), mapReq)
 	if err != nil {
+		if attributeError(c, err) {
+			return
+		}
 		switch err {
 		case errors.ErrUserAlreadyExists:
 			response.Error(c, message.FAILED_USER_ALREADY_EXISTS, err.Error(), 409)
+		case errors.ErrEmailDomainNotAllowed, errors.ErrEmailDomainBlocked, errors.ErrDisposableEmail:
+			response.Error(c, message.FAILED_EMAIL_DOMAIN_REJECTED, err.Error(), 422)
+		case errors.ErrInvalidInput, errors.ErrInvalidEmail, errors.ErrInvalidName:
+			response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
@@ -95,20 +117,37 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	mapReq := mapper.MapUpdateUserRequestToService(&req)
 
+	versions, ok := ifMatchVersions(c)
+	if !ok {
+		response.Error(c, message.FAILED_PRECONDITION, errors.ErrVersionConflict.Error(), 412)
+		return
+	}
+	mapReq.Versions = versions
+
 	result, err := h.userService.UpdateUser(c.Request.Context(), userUUID, mapReq)
 	if err != nil {
+		if attributeError(c, err) {
+			return
+		}
 		switch err {
 		case errors.ErrUserNotFound:
 			response.Error(c, message.FAILED_GET_USER_BY_ID, err.Error(), 404)
 		case errors.ErrUserAlreadyExists:
 			response.Error(c, message.FAILED_USER_ALREADY_EXISTS, err.Error(), 409)
+		case errors.ErrUsernameReserved:
+			response.Error(c, message.FAILED_USERNAME_RESERVED, err.Error(), 409)
+		case errors.ErrUsernameChangeCooldown:
+			response.Error(c, message.FAILED_USERNAME_CHANGE_COOLDOWN, err.Error(), 429)
+		case errors.ErrVersionConflict:
+			response.Error(c, message.FAILED_PRECONDITION, err.Error(), 412)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
 		return
 	}
 
-	response.Success(c, message.SUCCESS_UPDATE_USER, result, 200)
+	setETag(c, result.Version)
+	response.Success(c, message.SUCCESS_UPDATE_USER, mapper.MapUserInfoToDTO(result), 200)
 }
 
 func (h *UserHandler) UpdateUser(c *gin.Context) {
@@ -126,20 +165,200 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	mapReq := mapper.MapUpdateUserRequestToService(&req)
 
-	result, err := h.userService.UpdateUser(c.Request.Context(), userUUID, mapReq)
+	versions, ok := ifMatchVersions(c)
+	if !ok {
+		response.Error(c, message.FAILED_PRECONDITION, errors.ErrVersionConflict.Error(), 412)
+		return
+	}
+	mapReq.Versions = versions
+
+	result, err := h.userService.UpdateUserByAdmin(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userUUID, mapReq)
 	if err != nil {
+		if attributeError(c, err) {
+			return
+		}
 		switch err {
 		case errors.ErrUserNotFound:
 			response.Error(c, message.FAILED_GET_USER_BY_ID, err.Error(), 404)
 		case errors.ErrUserAlreadyExists:
 			response.Error(c, message.FAILED_USER_ALREADY_EXISTS, err.Error(), 409)
+		case errors.ErrUsernameReserved:
+			response.Error(c, message.FAILED_USERNAME_RESERVED, err.Error(), 409)
+		case errors.ErrUsernameChangeCooldown:
+			response.Error(c, message.FAILED_USERNAME_CHANGE_COOLDOWN, err.Error(), 429)
+		case errors.ErrVersionConflict:
+			response.Error(c, message.FAILED_PRECONDITION, err.Error(), 412)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
 		return
 	}
 
-	response.Success(c, message.SUCCESS_UPDATE_USER, result, 200)
+	setETag(c, result.Version)
+	response.Success(c, message.SUCCESS_UPDATE_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) PatchProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, message.FAILED_UNAUTHORIZED, errors.ErrInvalidCredentials.Error(), 401)
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	h.patchUser(c, userUUID, h.userService.UpdateUser)
+}
+
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	userUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	h.patchUser(c, userUUID, func(ctx context.Context, userID uuid.UUID, req *services.UpdateUserRequest) (*services.UserInfo, error) {
+		return h.userService.UpdateUserByAdmin(ctx, adminID, userID, req)
+	})
+}
+
+// userUpdater saves a patched user; admins and users editing their own
+// profile go through different UserService methods.
+type userUpdater func(ctx context.Context, userID uuid.UUID, req *services.UpdateUserRequest) (*services.UserInfo, error)
+
+// patchUser applies a JSON Merge Patch or JSON Patch to the updatable fields
+// of a user. The patch runs against the current user and the whole result is
+// validated before UserService sees it, which then only receives the fields
+// that changed.
+func (h *UserHandler) patchUser(c *gin.Context, userID uuid.UUID, update userUpdater) {
+	mediaType := c.ContentType()
+	if !patch.Supports(mediaType) {
+		c.Header("Accept-Patch", strings.Join(patch.MediaTypes, ", "))
+		response.Error(c, message.FAILED_UNSUPPORTED_PATCH, errors.ErrUnsupportedPatchType.Error(), 415)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	versions, ok := ifMatchVersions(c)
+	if !ok {
+		response.Error(c, message.FAILED_PRECONDITION, errors.ErrVersionConflict.Error(), 412)
+		return
+	}
+
+	for attempt := 1; ; attempt++ {
+		current, err := h.userService.GetUserByID(c.Request.Context(), userID)
+		if err != nil {
+			switch err {
+			case errors.ErrUserNotFound:
+				response.Error(c, message.FAILED_GET_USER_BY_ID, err.Error(), 404)
+			default:
+				response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+			}
+			return
+		}
+
+		if versions != nil && !slices.Contains(versions, current.Version) {
+			response.Error(c, message.FAILED_PRECONDITION, errors.ErrVersionConflict.Error(), 412)
+			return
+		}
+
+		mapReq, ok := h.applyUserPatch(c, mediaType, current, body)
+		if !ok {
+			return
+		}
+		if mapReq == nil {
+			setETag(c, current.Version)
+			response.Success(c, message.SUCCESS_UPDATE_USER, mapper.MapUserInfoToDTO(current), 200)
+			return
+		}
+
+		// The patch was computed from this version, so it must not land on
+		// any other.
+		mapReq.Versions = []int{current.Version}
+
+		result, err := update(c.Request.Context(), userID, mapReq)
+		if err == errors.ErrVersionConflict && versions == nil && attempt < patchAttempts {
+			continue
+		}
+		if err != nil {
+			if attributeError(c, err) {
+				return
+			}
+			switch err {
+			case errors.ErrUserNotFound:
+				response.Error(c, message.FAILED_GET_USER_BY_ID, err.Error(), 404)
+			case errors.ErrUserAlreadyExists:
+				response.Error(c, message.FAILED_USER_ALREADY_EXISTS, err.Error(), 409)
+			case errors.ErrUsernameReserved:
+				response.Error(c, message.FAILED_USERNAME_RESERVED, err.Error(), 409)
+			case errors.ErrUsernameChangeCooldown:
+				response.Error(c, message.FAILED_USERNAME_CHANGE_COOLDOWN, err.Error(), 429)
+			case errors.ErrVersionConflict:
+				response.Error(c, message.FAILED_PRECONDITION, err.Error(), 412)
+			default:
+				response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+			}
+			return
+		}
+
+		setETag(c, result.Version)
+		response.Success(c, message.SUCCESS_UPDATE_USER, mapper.MapUserInfoToDTO(result), 200)
+		return
+	}
+}
+
+// applyUserPatch patches the editable view of user and turns the result into
+// an update request, or nil when the patch changes nothing. It writes the
+// error response itself and reports false when the patch is rejected.
+func (h *UserHandler) applyUserPatch(c *gin.Context, mediaType string, user *services.UserInfo, body []byte) (*services.UpdateUserRequest, bool) {
+	document, err := json.Marshal(mapper.MapUserInfoToPatchDocument(user))
+	if err != nil {
+		response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		return nil, false
+	}
+
+	patched, err := patch.Apply(mediaType, document, body)
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidPatch:
+			response.Error(c, message.FAILED_INVALID_PATCH, err.Error(), 400)
+		case errors.ErrPatchConflict, errors.ErrPatchTestFailed:
+			response.Error(c, message.FAILED_PATCH_CONFLICT, err.Error(), 409)
+		default:
+			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		}
+		return nil, false
+	}
+
+	// Round trip the original too so both sides compare as decoded JSON.
+	var original, result dto.UserPatchDocument
+	if err := json.Unmarshal(document, &original); err != nil {
+		response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		response.Error(c, message.FAILED_INVALID_PATCH_USER, err.Error(), 422)
+		return nil, false
+	}
+	if err := binding.Validator.ValidateStruct(&result); err != nil {
+		response.Error(c, message.FAILED_INVALID_PATCH_USER, err.Error(), 422)
+		return nil, false
+	}
+
+	return mapper.MapUserPatchToService(&original, &result), true
 }
 
 func (h *UserHandler) ChangePassword(c *gin.Context) {
@@ -170,6 +389,8 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 			response.Error(c, message.FAILED_GET_USER_BY_ID, err.Error(), 404)
 		case errors.ErrInvalidCredentials:
 			response.Error(c, message.FAILED_PASSWORD_INCORRECT, "", 400)
+		case errors.ErrPasswordReused:
+			response.Error(c, message.FAILED_PASSWORD_REUSED, err.Error(), 400)
 		default:
 			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
 		}
@@ -180,26 +401,30 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 }
 
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
-	var req dto.PaginationRequest
-
-	pageStr := c.DefaultQuery("page", "1")
-	pageSizeStr := c.DefaultQuery("page_size", "10")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
+	var req dto.ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
 	}
+	req.Attributes = c.QueryMap("attributes")
 
-	pageSize, err := strconv.Atoi(pageSizeStr)
-	if err != nil || pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+	if req.IncludeDeleted && c.GetString("user_role") != entity.RoleAdmin {
+		response.Error(c, message.FAILED_FORBIDDEN, errors.ErrInsufficientPermissions.Error(), 403)
+		return
 	}
 
-	req.Search = c.Query("search")
-
-	result, err := h.userService.GetAllUsers(c.Request.Context(), page, pageSize, req.Search)
+	result, err := h.userService.GetAllUsers(c.Request.Context(), mapper.MapListUsersRequestToService(&req))
 	if err != nil {
-		response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		if _, ok := err.(*errors.AttributeError); ok {
+			response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+			return
+		}
+		switch err {
+		case errors.ErrInvalidSortField, errors.ErrInvalidCursor:
+			response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		default:
+			response.Error(c, message.FAILED_GET_ALL_USERS, err.Error(), 500)
+		}
 		return
 	}
 
@@ -208,6 +433,8 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 		PageSize:   result.PageSize,
 		Total:      result.Total,
 		TotalPages: result.TotalPages,
+		NextCursor: result.NextCursor,
+		PrevCursor: result.PrevCursor,
 	}
 
 	response.SuccessWithMeta(c, message.SUCCESS_GET_ALL_USERS, result.Datas, meta)
@@ -232,7 +459,36 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, message.SUCCESS_GET_USER_BY_ID, result, 200)
+	setETag(c, result.Version)
+	response.Success(c, message.SUCCESS_GET_USER_BY_ID, mapper.MapUserInfoToDTO(result), 200)
+}
+
+// GetUserByUsername redirects lookups by an old username to the user's
+// current one while the old username is kept as an alias.
+func (h *UserHandler) GetUserByUsername(c *gin.Context) {
+	username := c.Param("username")
+
+	result, err := h.userService.GetUserByUsername(c.Request.Context(), username)
+	if err != nil {
+		switch err {
+		case errors.ErrUserNotFound:
+			response.Error(c, message.FAILED_GET_USER_BY_USERNAME, err.Error(), 404)
+		default:
+			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		}
+		return
+	}
+
+	if result.Username != username {
+		// Aliases expire and the name may be taken by someone else later, so
+		// the redirect must not be cached as permanent.
+		location := strings.TrimSuffix(c.Request.URL.Path, username) + url.PathEscape(result.Username)
+		c.Redirect(http.StatusTemporaryRedirect, location)
+		return
+	}
+
+	setETag(c, result.Version)
+	response.Success(c, message.SUCCESS_GET_USER_BY_USERNAME, mapper.MapUserInfoToDTO(result), 200)
 }
 
 func (h *UserHandler) DeleteUser(c *gin.Context) {
@@ -243,11 +499,13 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	err = h.userService.DeleteUser(c.Request.Context(), userID)
+	err = h.userService.DeleteUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID)
 	if err != nil {
 		switch err {
 		case errors.ErrUserNotFound:
 			response.Error(c, message.FAILED_GET_USER_BY_ID, err.Error(), 404)
+		case errors.ErrDeleteSelf:
+			response.Error(c, message.FAILED_DELETE_SELF, err.Error(), 403)
 		case errors.ErrDeleteUser:
 			response.Error(c, message.FAILED_DELETE_USER, err.Error(), 500)
 		default:
@@ -257,3 +515,297 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	}
 	response.Success(c, message.SUCCESS_DELETE_USER, nil, 204)
 }
+
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	result, err := h.userService.RestoreUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID)
+	if err != nil {
+		switch err {
+		case errors.ErrUserNotFound:
+			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		case errors.ErrUserNotDeleted:
+			response.Error(c, message.FAILED_USER_NOT_DELETED, err.Error(), 409)
+		default:
+			response.Error(c, message.FAILED_RESTORE_USER, err.Error(), 500)
+		}
+		return
+	}
+
+	response.Success(c, message.SUCCESS_RESTORE_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) ResendInvitation(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	err = h.userService.ResendInvitation(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case errors.ErrUserNotFound:
+			response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+		case errors.ErrInvitationAlreadyAccepted:
+			response.Error(c, message.FAILED_INVITATION_ALREADY_ACCEPTED, err.Error(), 409)
+		default:
+			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		}
+		return
+	}
+
+	response.Success(c, message.SUCCESS_RESEND_INVITATION, nil, 200)
+}
+
+func (h *UserHandler) RevokeInvitation(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	err = h.userService.RevokeInvitation(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case errors.ErrInvitationNotFound:
+			response.Error(c, message.FAILED_INVITATION_NOT_FOUND, err.Error(), 404)
+		default:
+			response.Error(c, message.FAILED_INTERNAL_SERVER_ERROR, err.Error(), 500)
+		}
+		return
+	}
+
+	response.Success(c, message.SUCCESS_REVOKE_INVITATION, nil, 200)
+}
+
+func (h *UserHandler) ListPendingApprovals(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	result, err := h.userService.ListPendingApprovals(c.Request.Context(), page, pageSize)
+	if err != nil {
+		response.Error(c, message.FAILED_GET_PENDING_APPROVALS, err.Error(), 500)
+		return
+	}
+
+	meta := &response.Meta{
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}
+
+	response.SuccessWithMeta(c, message.SUCCESS_GET_PENDING_APPROVALS, result.Datas, meta)
+}
+
+func (h *UserHandler) ApproveUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	result, err := h.userService.ApproveUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID)
+	if err != nil {
+		h.approvalError(c, message.FAILED_APPROVE_USER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_APPROVE_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) RejectUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.RejectUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.userService.RejectUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID, req.Reason)
+	if err != nil {
+		h.approvalError(c, message.FAILED_REJECT_USER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_REJECT_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) approvalError(c *gin.Context, msg string, err error) {
+	switch err {
+	case errors.ErrUserNotFound:
+		response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+	case errors.ErrNotPendingApproval:
+		response.Error(c, message.FAILED_NOT_PENDING_APPROVAL, err.Error(), 409)
+	default:
+		response.Error(c, msg, err.Error(), 500)
+	}
+}
+
+func (h *UserHandler) SuspendUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.userService.SuspendUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID, mapper.MapSuspendUserRequestToService(&req))
+	if err != nil {
+		h.statusChangeError(c, message.FAILED_SUSPEND_USER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_SUSPEND_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) UnsuspendUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	result, err := h.userService.UnsuspendUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID)
+	if err != nil {
+		h.statusChangeError(c, message.FAILED_UNSUSPEND_USER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_UNSUSPEND_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) ResetUserPassword(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	err = h.userService.ResetUserPassword(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID)
+	if err != nil {
+		h.statusChangeError(c, message.FAILED_RESET_USER_PASSWORD, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_RESET_USER_PASSWORD, nil, 200)
+}
+
+func (h *UserHandler) ResendVerification(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	err = h.userService.ResendVerification(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID)
+	if err != nil {
+		h.statusChangeError(c, message.FAILED_RESEND_VERIFICATION, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_RESEND_VERIFICATION, nil, 200)
+}
+
+func (h *UserHandler) ActivateUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	result, err := h.userService.ActivateUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID)
+	if err != nil {
+		h.statusChangeError(c, message.FAILED_ACTIVATE_USER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_ACTIVATE_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, message.FAILED_INVALID_ID_FORMAT, errors.ErrInvalidIDFormat.Error(), 400)
+		return
+	}
+
+	var req dto.DeactivateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+		return
+	}
+
+	result, err := h.userService.DeactivateUser(c.Request.Context(), c.MustGet("user_id").(uuid.UUID), userID, req.Reason)
+	if err != nil {
+		h.statusChangeError(c, message.FAILED_DEACTIVATE_USER, err)
+		return
+	}
+
+	response.Success(c, message.SUCCESS_DEACTIVATE_USER, mapper.MapUserInfoToDTO(result), 200)
+}
+
+func (h *UserHandler) statusChangeError(c *gin.Context, msg string, err error) {
+	switch err {
+	case errors.ErrInvalidInput:
+		response.Error(c, message.FAILED_INVALID_REQUEST_FORMAT, err.Error(), 400)
+	case errors.ErrUserNotFound:
+		response.Error(c, message.FAILED_USER_NOT_FOUND, err.Error(), 404)
+	case errors.ErrUserNotSuspended:
+		response.Error(c, message.FAILED_USER_NOT_SUSPENDED, err.Error(), 409)
+	case errors.ErrInvalidStatusTransition:
+		response.Error(c, message.FAILED_INVALID_STATUS_CHANGE, err.Error(), 409)
+	case errors.ErrUserVerified:
+		response.Error(c, message.FAILED_USER_VERIFIED, err.Error(), 409)
+	case errors.ErrInvitationPending:
+		response.Error(c, message.FAILED_INVITATION_PENDING, err.Error(), 409)
+	case errors.ErrApprovalPending:
+		response.Error(c, message.FAILED_APPROVAL_PENDING, err.Error(), 409)
+	case errors.ErrRegistrationRejected:
+		response.Error(c, message.FAILED_REGISTRATION_REJECTED, err.Error(), 409)
+	case errors.ErrDeactivateSelf:
+		response.Error(c, message.FAILED_DEACTIVATE_SELF, err.Error(), 403)
+	case errors.ErrSuspendSelf:
+		response.Error(c, message.FAILED_SUSPEND_SELF, err.Error(), 403)
+	default:
+		response.Error(c, msg, err.Error(), 500)
+	}
+}
+
+// attributeError responds to custom attribute validation failures and
+// reports whether err was one.
+func attributeError(c *gin.Context, err error) bool {
+	attributeErr, ok := err.(*errors.AttributeError)
+	if !ok {
+		return false
+	}
+
+	if attributeErr.Err == errors.ErrAttributeNotUnique {
+		response.Error(c, message.FAILED_ATTRIBUTE_NOT_UNIQUE, err.Error(), 409)
+	} else {
+		response.Error(c, message.FAILED_INVALID_ATTRIBUTES, err.Error(), 422)
+	}
+	return true
+}
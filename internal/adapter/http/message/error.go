@@ -15,12 +15,100 @@ const (
 	FAILED_INVALID_REQUEST_FORMAT   = "Invalid request format"
 	FAILED_INVALID_ID_FORMAT        = "Invalid ID format"
 	FAILED_PASSWORD_INCORRECT       = "Current password is incorrect"
+	FAILED_CSRF_TOKEN_INVALID       = "CSRF token invalid"
+	FAILED_PASSWORD_CHANGE_REQUIRED = "Password change required"
+	FAILED_PASSWORD_REUSED          = "New password must be different"
+	FAILED_ACCOUNT_SUSPENDED        = "Account is suspended"
+	FAILED_ACCOUNT_DEACTIVATED      = "Account is deactivated"
+	FAILED_PRECONDITION             = "Resource was modified by another request"
 
 	FAILED_GET_ALL_USERS       = "Failed to get all users"
 	FAILED_GET_USER_BY_ID      = "Failed to get user by id"
 	FAILED_CREATE_USER         = "Failed to create user"
 	FAILED_UPDATE_USER         = "Failed to update user"
 	FAILED_DELETE_USER         = "Failed to delete user"
+	FAILED_UNSUPPORTED_PATCH   = "Unsupported patch format"
+	FAILED_INVALID_PATCH       = "Invalid patch document"
+	FAILED_PATCH_CONFLICT      = "Patch cannot be applied to the current user"
+	FAILED_INVALID_PATCH_USER  = "Patched user is invalid"
 	FAILED_USER_ALREADY_EXISTS = "User already exists"
 	FAILED_USER_NOT_FOUND      = "User not found"
+
+	FAILED_GET_USER_BY_USERNAME     = "Failed to get user by username"
+	FAILED_USERNAME_RESERVED        = "Username is reserved"
+	FAILED_USERNAME_CHANGE_COOLDOWN = "Username was changed too recently"
+	FAILED_RESTORE_USER             = "Failed to restore user"
+	FAILED_USER_NOT_DELETED         = "User is not deleted"
+
+	FAILED_EMAIL_DOMAIN_REJECTED = "Email domain rejected"
+
+	FAILED_INVALID_ATTRIBUTES          = "Invalid custom attributes"
+	FAILED_ATTRIBUTE_NOT_UNIQUE        = "Custom attribute value already taken"
+	FAILED_GET_ATTRIBUTE_DEFINITIONS   = "Failed to get attribute definitions"
+	FAILED_CREATE_ATTRIBUTE_DEFINITION = "Failed to create attribute definition"
+	FAILED_UPDATE_ATTRIBUTE_DEFINITION = "Failed to update attribute definition"
+	FAILED_DELETE_ATTRIBUTE_DEFINITION = "Failed to delete attribute definition"
+	FAILED_ATTRIBUTE_NOT_FOUND         = "Attribute definition not found"
+	FAILED_ATTRIBUTE_ALREADY_EXISTS    = "Attribute definition already exists"
+
+	FAILED_GET_GROUPS           = "Failed to get groups"
+	FAILED_GET_GROUP            = "Failed to get group"
+	FAILED_CREATE_GROUP         = "Failed to create group"
+	FAILED_UPDATE_GROUP         = "Failed to update group"
+	FAILED_DELETE_GROUP         = "Failed to delete group"
+	FAILED_GROUP_NOT_FOUND      = "Group not found"
+	FAILED_GROUP_ALREADY_EXISTS = "Group already exists"
+	FAILED_GROUP_HAS_SUBGROUPS  = "Group still has subgroups"
+	FAILED_GET_GROUP_MEMBERS    = "Failed to get group members"
+	FAILED_ADD_GROUP_MEMBER     = "Failed to add group member"
+	FAILED_REMOVE_GROUP_MEMBER  = "Failed to remove group member"
+	FAILED_GROUP_MEMBER_EXISTS  = "User is already a member of the group"
+	FAILED_NOT_GROUP_MEMBER     = "User is not a member of the group"
+
+	FAILED_GET_PREFERENCES     = "Failed to get preferences"
+	FAILED_UPDATE_PREFERENCES  = "Failed to update preferences"
+	FAILED_INVALID_PREFERENCES = "Invalid preferences"
+
+	FAILED_UPLOAD_AVATAR    = "Failed to upload avatar"
+	FAILED_DELETE_AVATAR    = "Failed to delete avatar"
+	FAILED_INVALID_AVATAR   = "Invalid avatar image"
+	FAILED_AVATAR_NOT_FOUND = "Avatar not found"
+
+	FAILED_GET_PENDING_APPROVALS = "Failed to get pending approvals"
+	FAILED_APPROVAL_PENDING      = "Account is awaiting approval"
+	FAILED_REGISTRATION_REJECTED = "Registration was rejected"
+	FAILED_NOT_PENDING_APPROVAL  = "User is not pending approval"
+	FAILED_APPROVE_USER          = "Failed to approve user"
+	FAILED_REJECT_USER           = "Failed to reject user"
+
+	FAILED_SUSPEND_USER          = "Failed to suspend user"
+	FAILED_UNSUSPEND_USER        = "Failed to unsuspend user"
+	FAILED_USER_NOT_SUSPENDED    = "User is not suspended"
+	FAILED_INVALID_STATUS_CHANGE = "Invalid account status change"
+	FAILED_RESET_USER_PASSWORD   = "Failed to reset user password"
+	FAILED_RESEND_VERIFICATION   = "Failed to resend verification email"
+	FAILED_USER_VERIFIED         = "User is already verified"
+	FAILED_ACTIVATE_USER         = "Failed to activate user"
+	FAILED_DEACTIVATE_USER       = "Failed to deactivate user"
+	FAILED_DEACTIVATE_SELF       = "Admins cannot deactivate their own account"
+	FAILED_SUSPEND_SELF          = "Admins cannot suspend their own account"
+	FAILED_DELETE_SELF           = "Admins cannot delete their own account"
+
+	FAILED_INVITATION_NOT_FOUND        = "Invitation not found"
+	FAILED_INVITATION_PENDING          = "Invitation has not been accepted yet"
+	FAILED_INVITATION_ALREADY_ACCEPTED = "Invitation already accepted"
+
+	FAILED_GET_AUDIT_EVENTS = "Failed to get audit events"
+
+	FAILED_START_IMPORT        = "Failed to start import"
+	FAILED_GET_IMPORT          = "Failed to get import"
+	FAILED_IMPORT_NOT_FOUND    = "Import not found"
+	FAILED_INVALID_IMPORT_FILE = "Invalid import file"
+	FAILED_IMPORT_TOO_LARGE    = "Import file too large"
+
+	FAILED_EXPORT_USERS = "Failed to export users"
+
+	FAILED_CAPTCHA_REQUIRED    = "CAPTCHA token required"
+	FAILED_CAPTCHA_INVALID     = "CAPTCHA verification failed"
+	FAILED_CAPTCHA_UNAVAILABLE = "CAPTCHA verification unavailable"
 )
@@ -10,11 +10,55 @@ const (
 	SUCCESS_SENT_VERIFY_EMAIL   = "Verification email sent successfully"
 	SUCCESS_SENT_RESET_PASSWORD = "Reset password email sent successfully"
 	SUCCESS_RESET_PASSWORD      = "Password reset successfully"
+	SUCCESS_SECURE_ACCOUNT      = "All sessions signed out and reset password email sent"
 
-	SUCCESS_GET_ALL_USERS   = "Success to get all users"
-	SUCCESS_GET_USER_BY_ID  = "Success to get user by id"
-	SUCCESS_CREATE_USER     = "Success to create user"
-	SUCCESS_UPDATE_USER     = "Success to update user"
-	SUCCESS_DELETE_USER     = "Success to delete user"
-	SUCCESS_CHANGE_PASSWORD = "Password changed successfully"
+	SUCCESS_GET_ALL_USERS        = "Success to get all users"
+	SUCCESS_GET_USER_BY_ID       = "Success to get user by id"
+	SUCCESS_GET_USER_BY_USERNAME = "Success to get user by username"
+	SUCCESS_CREATE_USER          = "Success to create user"
+	SUCCESS_UPDATE_USER          = "Success to update user"
+	SUCCESS_DELETE_USER          = "Success to delete user"
+	SUCCESS_RESTORE_USER         = "User restored successfully"
+	SUCCESS_CHANGE_PASSWORD      = "Password changed successfully"
+
+	SUCCESS_GET_ATTRIBUTE_DEFINITIONS   = "Success to get attribute definitions"
+	SUCCESS_CREATE_ATTRIBUTE_DEFINITION = "Attribute definition created successfully"
+	SUCCESS_UPDATE_ATTRIBUTE_DEFINITION = "Attribute definition updated successfully"
+	SUCCESS_DELETE_ATTRIBUTE_DEFINITION = "Attribute definition deleted successfully"
+
+	SUCCESS_GET_GROUPS          = "Success to get groups"
+	SUCCESS_GET_GROUP           = "Success to get group"
+	SUCCESS_CREATE_GROUP        = "Group created successfully"
+	SUCCESS_UPDATE_GROUP        = "Group updated successfully"
+	SUCCESS_DELETE_GROUP        = "Group deleted successfully"
+	SUCCESS_GET_GROUP_MEMBERS   = "Success to get group members"
+	SUCCESS_ADD_GROUP_MEMBER    = "Group member added successfully"
+	SUCCESS_REMOVE_GROUP_MEMBER = "Group member removed successfully"
+
+	SUCCESS_GET_PREFERENCES    = "Success to get preferences"
+	SUCCESS_UPDATE_PREFERENCES = "Preferences updated successfully"
+
+	SUCCESS_UPLOAD_AVATAR = "Avatar uploaded successfully"
+	SUCCESS_DELETE_AVATAR = "Avatar deleted successfully"
+
+	SUCCESS_GET_PENDING_APPROVALS = "Success to get pending approvals"
+	SUCCESS_APPROVE_USER          = "User approved successfully"
+	SUCCESS_REJECT_USER           = "User rejected successfully"
+
+	SUCCESS_SUSPEND_USER   = "User suspended successfully"
+	SUCCESS_UNSUSPEND_USER = "User unsuspended successfully"
+
+	SUCCESS_RESET_USER_PASSWORD = "Password reset and reset password email sent"
+	SUCCESS_RESEND_VERIFICATION = "Verification email resent successfully"
+	SUCCESS_ACTIVATE_USER       = "User activated successfully"
+	SUCCESS_DEACTIVATE_USER     = "User deactivated successfully"
+
+	SUCCESS_ACCEPT_INVITATION = "Invitation accepted successfully"
+	SUCCESS_RESEND_INVITATION = "Invitation resent successfully"
+	SUCCESS_REVOKE_INVITATION = "Invitation revoked successfully"
+
+	SUCCESS_GET_AUDIT_EVENTS = "Success to get audit events"
+
+	SUCCESS_START_IMPORT = "Import started successfully"
+	SUCCESS_GET_IMPORT   = "Success to get import"
 )
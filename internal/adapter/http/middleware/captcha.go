@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"log"
+
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const captchaHeader = "X-Captcha-Token"
+
+type CaptchaMiddleware struct {
+	verifier      ports.CaptchaVerifier
+	riskThreshold float64
+}
+
+// NewCaptchaMiddleware enforces CAPTCHA verification with the given verifier.
+// A nil verifier disables the check.
+func NewCaptchaMiddleware(verifier ports.CaptchaVerifier, cfg config.CaptchaConfig) *CaptchaMiddleware {
+	return &CaptchaMiddleware{
+		verifier:      verifier,
+		riskThreshold: cfg.RiskThreshold,
+	}
+}
+
+// Middleware requires a valid CAPTCHA token in the X-Captcha-Token header.
+// Requests are rejected when the provider cannot be reached.
+func (m *CaptchaMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.verifier == nil {
+			c.Next()
+			return
+		}
+
+		token := c.GetHeader(captchaHeader)
+		if token == "" {
+			response.Error(c, message.FAILED_CAPTCHA_REQUIRED, errors.ErrCaptchaRequired.Error(), 400)
+			c.Abort()
+			return
+		}
+
+		result, err := m.verifier.Verify(c.Request.Context(), token, c.ClientIP())
+		if err != nil {
+			log.Printf("captcha verification failed: %v", err)
+			response.Error(c, message.FAILED_CAPTCHA_UNAVAILABLE, errors.ErrCaptchaUnavailable.Error(), 503)
+			c.Abort()
+			return
+		}
+
+		if !result.Success || result.RiskScore > m.riskThreshold {
+			response.Error(c, message.FAILED_CAPTCHA_INVALID, errors.ErrCaptchaInvalid.Error(), 403)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -3,16 +3,25 @@ package middleware
 import (
 	"time"
 
+	"go-gin-hexagonal/pkg/config"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func CORSMiddleware() gin.HandlerFunc {
+func CORSMiddleware(cookieConfig config.CookieConfig) gin.HandlerFunc {
+	// Browsers refuse credentialed requests against a wildcard origin, so
+	// cookie mode only allows the frontend application.
+	allowOrigins := []string{"*"}
+	if cookieConfig.Enabled {
+		allowOrigins = []string{config.GetAppURL()}
+	}
+
 	return cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
+		AllowOrigins:     allowOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-CSRF-Token", "X-Request-ID", "X-Captcha-Token", "If-Match"},
+		ExposeHeaders:    []string{"Content-Length", "X-CSRF-Token", "X-Request-ID", "ETag", "Accept-Patch"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
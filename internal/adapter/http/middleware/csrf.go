@@ -1,31 +1,109 @@
 package middleware
 
 import (
-	"go-gin-hexagonal/pkg/utils"
+	"encoding/json"
 	"net/http"
-	"strings"
+	"net/url"
+
+	response "go-gin-hexagonal/internal/adapter/http"
+	"go-gin-hexagonal/internal/adapter/http/message"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/csrf"
 )
 
-func CSRFMiddleware() gin.HandlerFunc {
-	csrfKey := utils.GeneratePassword(32, false)
-	csrfProtection := csrf.Protect([]byte(csrfKey), csrf.Secure(false), csrf.MaxAge(600), csrf.CookieName("CSRF-TOKEN"))
+const csrfHeader = "X-CSRF-Token"
+
+// CSRFMiddleware validates CSRF tokens on the routes it is registered on.
+// Public endpoints that never rely on the auth cookies are registered without
+// it, so old cookies in the browser do not get in their way. Create it once:
+// every route has to share the same key.
+func CSRFMiddleware(cfg config.CookieConfig) gin.HandlerFunc {
+	csrfKey := cfg.CSRFKey
+	if csrfKey == "" {
+		csrfKey = utils.GeneratePassword(32, false)
+	}
+
+	csrfProtection := csrf.Protect(
+		[]byte(csrfKey),
+		csrf.Secure(cfg.Secure),
+		csrf.MaxAge(600),
+		csrf.CookieName("CSRF-TOKEN"),
+		csrf.Path("/"),
+		csrf.RequestHeader(csrfHeader),
+		csrf.TrustedOrigins(trustedOrigins()),
+		csrf.ErrorHandler(http.HandlerFunc(csrfErrorHandler)),
+	)
 
 	return func(c *gin.Context) {
-		if c.Request.Method == "GET" || c.Request.Method == "HEAD" || c.Request.Method == "OPTIONS" {
+		if !isSafeMethod(c.Request.Method) && !requiresCSRFCheck(c.Request, cfg) {
 			c.Next()
 			return
 		}
 
-		if strings.HasPrefix(c.Request.URL.Path, "/api/v1/auth") {
-			c.Next()
-			return
+		req := c.Request
+		if !cfg.Secure {
+			req = csrf.PlaintextHTTPRequest(req)
 		}
 
+		passed := false
 		csrfProtection(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			c.Next()
-		}))
+			passed = true
+			c.Request = r
+		})).ServeHTTP(c.Writer, req)
+
+		if !passed {
+			c.Abort()
+			return
+		}
+
+		c.Header(csrfHeader, csrf.Token(c.Request))
+		c.Next()
+	}
+}
+
+func csrfErrorHandler(w http.ResponseWriter, r *http.Request) {
+	errMsg := "CSRF token invalid"
+	if reason := csrf.FailureReason(r); reason != nil {
+		errMsg = reason.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(response.Response{
+		Status:  false,
+		Message: message.FAILED_CSRF_TOKEN_INVALID,
+		Error:   errMsg,
+	})
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// requiresCSRFCheck reports whether the request is authenticated by the
+// browser's ambient auth cookies. Requests that carry an Authorization header
+// cannot be forged cross-site and are left untouched.
+func requiresCSRFCheck(r *http.Request, cfg config.CookieConfig) bool {
+	if !cfg.Enabled || r.Header.Get("Authorization") != "" {
+		return false
+	}
+
+	for _, name := range []string{cfg.AccessTokenName, cfg.RefreshTokenName} {
+		if cookie, err := r.Cookie(name); err == nil && cookie.Value != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func trustedOrigins() []string {
+	appURL, err := url.Parse(config.GetAppURL())
+	if err != nil || appURL.Host == "" {
+		return nil
 	}
+	return []string{appURL.Host}
 }
@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"slices"
 	"strings"
 
 	response "go-gin-hexagonal/internal/adapter/http"
 	"go-gin-hexagonal/internal/adapter/http/message"
 	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
 	"go-gin-hexagonal/pkg/errors"
 
 	"github.com/gin-gonic/gin"
@@ -13,30 +15,60 @@ import (
 
 type AuthMiddleware struct {
 	tokenManager ports.TokenManager
+	cookieConfig config.CookieConfig
 }
 
-func NewAuthMiddleware(tokenManager ports.TokenManager) *AuthMiddleware {
+func NewAuthMiddleware(tokenManager ports.TokenManager, cookieConfig config.CookieConfig) *AuthMiddleware {
 	return &AuthMiddleware{
 		tokenManager: tokenManager,
+		cookieConfig: cookieConfig,
 	}
 }
 
-func (m *AuthMiddleware) Middleware() gin.HandlerFunc {
+// Optional authenticates the request like Middleware when it carries a valid
+// access token, and otherwise lets it through unauthenticated.
+func (m *AuthMiddleware) Optional(allowedScopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			response.Error(c, message.FAILED_GET_AUTHORIZATION_HEADER, errors.ErrAuthorizationHeaderNotFound.Error(), 401)
-			c.Abort()
-			return
+		var token string
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			token = strings.TrimPrefix(authHeader, "Bearer ")
+		} else if cookie, err := c.Cookie(m.cookieConfig.AccessTokenName); m.cookieConfig.Enabled && err == nil {
+			token = cookie
 		}
 
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			response.Error(c, message.FAILED_TOKEN_INVALID, errors.ErrTokenInvalid.Error(), 401)
+		if token != "" {
+			claims, err := m.tokenManager.ValidateAccessToken(token)
+			if err == nil && (claims.Scope == "" || slices.Contains(allowedScopes, claims.Scope)) {
+				setClaims(c, claims)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// Middleware authenticates the request with an access token. Scoped tokens are
+// rejected unless their scope is listed in allowedScopes.
+func (m *AuthMiddleware) Middleware(allowedScopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+
+		var token string
+		if authHeader != "" {
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				response.Error(c, message.FAILED_TOKEN_INVALID, errors.ErrTokenInvalid.Error(), 401)
+				c.Abort()
+				return
+			}
+			token = strings.TrimPrefix(authHeader, "Bearer ")
+		} else if cookie, err := c.Cookie(m.cookieConfig.AccessTokenName); m.cookieConfig.Enabled && err == nil {
+			token = cookie
+		} else {
+			response.Error(c, message.FAILED_GET_AUTHORIZATION_HEADER, errors.ErrAuthorizationHeaderNotFound.Error(), 401)
 			c.Abort()
 			return
 		}
 
-		token := strings.TrimPrefix(authHeader, "Bearer ")
 		if token == "" {
 			response.Error(c, message.FAILED_TOKEN_NOT_FOUND, errors.ErrTokenNotFound.Error(), 401)
 			c.Abort()
@@ -50,9 +82,34 @@ func (m *AuthMiddleware) Middleware() gin.HandlerFunc {
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_username", claims.Username)
+		if claims.Scope != "" && !slices.Contains(allowedScopes, claims.Scope) {
+			response.Error(c, message.FAILED_PASSWORD_CHANGE_REQUIRED, errors.ErrPasswordChangeRequired.Error(), 403)
+			c.Abort()
+			return
+		}
+
+		setClaims(c, claims)
+		c.Next()
+	}
+}
+
+func setClaims(c *gin.Context, claims *ports.AccessTokenClaims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_username", claims.Username)
+	c.Set("user_role", claims.Role)
+	c.Set("token_scope", claims.Scope)
+}
+
+// RequireRole only lets through requests authenticated by Middleware whose
+// token carries one of the given roles.
+func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !slices.Contains(roles, c.GetString("user_role")) {
+			response.Error(c, message.FAILED_FORBIDDEN, errors.ErrInsufficientPermissions.Error(), 403)
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
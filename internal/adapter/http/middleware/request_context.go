@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"go-gin-hexagonal/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// RequestContextMiddleware tags every request with a request ID, reusing the
+// one set by an upstream proxy when present, and exposes it together with the
+// client IP and user agent to the services through the request context.
+func RequestContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = uuid.NewString()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(requestIDHeader, requestID)
+
+		c.Request = c.Request.WithContext(utils.WithRequestMetadata(c.Request.Context(), utils.RequestMetadata{
+			RequestID: requestID,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		}))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,354 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-gin-hexagonal/pkg/errors"
+)
+
+const (
+	// MergePatchType is a JSON Merge Patch as defined by RFC 7396.
+	MergePatchType = "application/merge-patch+json"
+	// JSONPatchType is a list of JSON Patch operations as defined by RFC 6902.
+	JSONPatchType = "application/json-patch+json"
+)
+
+// MediaTypes lists the patch formats Apply understands, in the form used by
+// the Accept-Patch header.
+var MediaTypes = []string{MergePatchType, JSONPatchType}
+
+func Supports(mediaType string) bool {
+	return slices.Contains(MediaTypes, mediaType)
+}
+
+// Apply applies a patch of the given media type to a JSON document and
+// returns the patched document. The document itself is left untouched.
+func Apply(mediaType string, document, patch []byte) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, err
+	}
+
+	var result any
+	var err error
+	switch mediaType {
+	case MergePatchType:
+		result, err = applyMergePatch(doc, patch)
+	case JSONPatchType:
+		result, err = applyJSONPatch(doc, patch)
+	default:
+		return nil, errors.ErrUnsupportedPatchType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+func applyMergePatch(doc any, data []byte) (any, error) {
+	var patch any
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, errors.ErrInvalidPatch
+	}
+	return mergePatch(doc, patch), nil
+}
+
+// mergePatch follows the MergePatch pseudocode of RFC 7396: objects are
+// merged key by key, null removes a key and anything else replaces the target.
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any)
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyJSONPatch runs the operations in order. Any failing operation fails
+// the whole patch, so callers never see a partly applied document.
+func applyJSONPatch(doc any, data []byte) (any, error) {
+	var operations []operation
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return nil, errors.ErrInvalidPatch
+	}
+
+	for _, op := range operations {
+		if op.Path == nil {
+			return nil, errors.ErrInvalidPatch
+		}
+		path, err := parsePointer(*op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			// A missing value is an error, unlike an explicit null.
+			if op.Value == nil {
+				return nil, errors.ErrInvalidPatch
+			}
+			var value any
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return nil, errors.ErrInvalidPatch
+			}
+
+			switch op.Op {
+			case "add":
+				doc, err = add(doc, path, value)
+			case "replace":
+				doc, err = replace(doc, path, value)
+			case "test":
+				err = test(doc, path, value)
+			}
+		case "remove":
+			doc, err = remove(doc, path)
+		case "move", "copy":
+			if op.From == nil {
+				return nil, errors.ErrInvalidPatch
+			}
+			var from []string
+			if from, err = parsePointer(*op.From); err != nil {
+				return nil, err
+			}
+
+			if op.Op == "move" {
+				doc, err = move(doc, from, path)
+			} else {
+				doc, err = copyValue(doc, from, path)
+			}
+		default:
+			return nil, errors.ErrInvalidPatch
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped reference
+// tokens. The empty pointer refers to the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.ErrInvalidPatch
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(token), "~") {
+			return nil, errors.ErrInvalidPatch
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses an array reference token, which must be a decimal number
+// without leading zeros.
+func arrayIndex(token string, length int) (int, bool) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > length || strconv.Itoa(index) != token {
+		return 0, false
+	}
+	return index, true
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, errors.ErrPatchConflict
+			}
+			doc = value
+		case []any:
+			index, ok := arrayIndex(token, len(node)-1)
+			if !ok {
+				return nil, errors.ErrPatchConflict
+			}
+			doc = node[index]
+		default:
+			return nil, errors.ErrPatchConflict
+		}
+	}
+	return doc, nil
+}
+
+// update walks to the container holding the last token of path, lets fn
+// produce its replacement and rebuilds the path back up to the root.
+func update(doc any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+
+	child, err := get(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = update(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node := doc.(type) {
+	case map[string]any:
+		node[path[0]] = child
+	case []any:
+		index, _ := arrayIndex(path[0], len(node)-1)
+		node[index] = child
+	}
+	return doc, nil
+}
+
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			index := len(node)
+			if token != "-" {
+				var ok bool
+				if index, ok = arrayIndex(token, len(node)); !ok {
+					return nil, errors.ErrPatchConflict
+				}
+			}
+			return slices.Insert(node, index, value), nil
+		default:
+			return nil, errors.ErrPatchConflict
+		}
+	})
+}
+
+func remove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	return update(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			if _, ok := node[token]; !ok {
+				return nil, errors.ErrPatchConflict
+			}
+			delete(node, token)
+			return node, nil
+		case []any:
+			index, ok := arrayIndex(token, len(node)-1)
+			if !ok {
+				return nil, errors.ErrPatchConflict
+			}
+			return slices.Delete(node, index, index+1), nil
+		default:
+			return nil, errors.ErrPatchConflict
+		}
+	})
+}
+
+func replace(doc any, path []string, value any) (any, error) {
+	if _, err := get(doc, path); err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+		case []any:
+			index, _ := arrayIndex(token, len(node)-1)
+			node[index] = value
+		}
+		return container, nil
+	})
+}
+
+func move(doc any, from, path []string) (any, error) {
+	// A value cannot be moved into one of its own children.
+	if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+		return nil, errors.ErrInvalidPatch
+	}
+
+	value, err := get(doc, from)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = remove(doc, from)
+	if err != nil {
+		return nil, err
+	}
+	return add(doc, path, value)
+}
+
+func copyValue(doc any, from, path []string) (any, error) {
+	value, err := get(doc, from)
+	if err != nil {
+		return nil, err
+	}
+	return add(doc, path, deepCopy(value))
+}
+
+func test(doc any, path []string, value any) error {
+	current, err := get(doc, path)
+	if err != nil {
+		return errors.ErrPatchTestFailed
+	}
+	if !reflect.DeepEqual(current, value) {
+		return errors.ErrPatchTestFailed
+	}
+	return nil
+}
+
+func deepCopy(value any) any {
+	switch node := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(node))
+		for key, child := range node {
+			copied[key] = deepCopy(child)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(node))
+		for i, child := range node {
+			copied[i] = deepCopy(child)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -19,6 +19,9 @@ type Meta struct {
 	PageSize   int   `json:"page_size,omitempty"`
 	Total      int64 `json:"total,omitempty"`
 	TotalPages int   `json:"total_pages,omitempty"`
+	// Keyset pagination
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func Success(c *gin.Context, message string, data any, code int) {
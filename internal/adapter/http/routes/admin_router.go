@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"go-gin-hexagonal/internal/adapter/http/handlers"
+	"go-gin-hexagonal/internal/adapter/http/middleware"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminRoutes(rg *gin.RouterGroup, auditHandler *handlers.AuditHandler, userHandler *handlers.UserHandler, importHandler *handlers.ImportHandler, exportHandler *handlers.ExportHandler, attributeHandler *handlers.AttributeHandler, groupHandler *handlers.GroupHandler, authMiddleware *middleware.AuthMiddleware, groupMiddleware *middleware.GroupMiddleware, exportConfig config.ExportConfig) {
+	admin := rg.Group("/admin")
+	admin.Use(authMiddleware.Middleware(), authMiddleware.RequireRole(entity.RoleAdmin))
+	{
+		admin.GET("/audit-events", auditHandler.ListAuditEvents)
+
+		admin.GET("/approvals", userHandler.ListPendingApprovals)
+		admin.POST("/approvals/:id/approve", userHandler.ApproveUser)
+		admin.POST("/approvals/:id/reject", userHandler.RejectUser)
+
+		admin.GET("/users/export", groupMiddleware.RequireGroup(exportConfig.AllowedGroups...), exportHandler.ExportUsers)
+		admin.POST("/users/:id/suspend", userHandler.SuspendUser)
+		admin.POST("/users/:id/unsuspend", userHandler.UnsuspendUser)
+		admin.POST("/users/:id/restore", userHandler.RestoreUser)
+		admin.POST("/users/:id/activate", userHandler.ActivateUser)
+		admin.POST("/users/:id/deactivate", userHandler.DeactivateUser)
+		admin.POST("/users/:id/password-reset", userHandler.ResetUserPassword)
+		admin.POST("/users/:id/verification", userHandler.ResendVerification)
+		admin.GET("/users/:id/groups", groupHandler.ListUserGroups)
+
+		admin.POST("/imports", importHandler.StartImport)
+		admin.GET("/imports/:id", importHandler.GetImport)
+
+		admin.GET("/attributes", attributeHandler.ListDefinitions)
+		admin.POST("/attributes", attributeHandler.CreateDefinition)
+		admin.PUT("/attributes/:id", attributeHandler.UpdateDefinition)
+		admin.DELETE("/attributes/:id", attributeHandler.DeleteDefinition)
+
+		admin.GET("/groups", groupHandler.ListGroups)
+		admin.POST("/groups", groupHandler.CreateGroup)
+		admin.GET("/groups/:id", groupHandler.GetGroup)
+		admin.PUT("/groups/:id", groupHandler.UpdateGroup)
+		admin.DELETE("/groups/:id", groupHandler.DeleteGroup)
+		admin.GET("/groups/:id/members", groupHandler.ListMembers)
+		admin.POST("/groups/:id/members", groupHandler.AddMember)
+		admin.DELETE("/groups/:id/members/:user_id", groupHandler.RemoveMember)
+	}
+}
@@ -3,25 +3,33 @@ package routes
 import (
 	"go-gin-hexagonal/internal/adapter/http/handlers"
 	"go-gin-hexagonal/internal/adapter/http/middleware"
+	"go-gin-hexagonal/internal/domain/ports"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterAuthRoutes(rg *gin.RouterGroup, authHandler *handlers.AuthHandler, authMiddleware *middleware.AuthMiddleware) {
+// RegisterAuthRoutes registers the auth endpoints. Only refresh and logout act
+// on the auth cookies, so only they are CSRF protected.
+func RegisterAuthRoutes(rg *gin.RouterGroup, authHandler *handlers.AuthHandler, authMiddleware *middleware.AuthMiddleware, captchaMiddleware *middleware.CaptchaMiddleware, csrfMiddleware gin.HandlerFunc) {
 	auth := rg.Group("/auth")
 	{
 		auth.POST("/login", authHandler.Login)
-		auth.POST("/register", authHandler.Register)
 		auth.POST("/verify-email", authHandler.VerifyEmail)
-		auth.POST("/send-verify-email", authHandler.SendVerifyEmail)
 		auth.POST("/reset-password", authHandler.ResetPassword)
-		auth.POST("/send-reset-password", authHandler.SendResetPassword)
+		auth.POST("/refresh", csrfMiddleware, authHandler.RefreshToken)
+		auth.POST("/accept-invitation", authHandler.AcceptInvitation)
+		auth.POST("/secure-account", authHandler.SecureAccount)
+		// Logout also accepts the refresh token, so sessions whose access
+		// token expired can still end.
+		auth.POST("/logout", csrfMiddleware, authMiddleware.Optional(ports.TokenScopePasswordChange), authHandler.Logout)
 
-		authProtected := auth.Group("")
-		authProtected.Use(authMiddleware.Middleware())
+		// Public endpoints that send email to an arbitrary address.
+		authCaptcha := auth.Group("")
+		authCaptcha.Use(captchaMiddleware.Middleware())
 		{
-			authProtected.POST("/refresh", authHandler.RefreshToken)
-			authProtected.POST("/logout", authHandler.Logout)
+			authCaptcha.POST("/register", authHandler.Register)
+			authCaptcha.POST("/send-verify-email", authHandler.SendVerifyEmail)
+			authCaptcha.POST("/send-reset-password", authHandler.SendResetPassword)
 		}
 	}
 }
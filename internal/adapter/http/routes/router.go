@@ -3,34 +3,72 @@ package routes
 import (
 	"go-gin-hexagonal/internal/adapter/http/handlers"
 	"go-gin-hexagonal/internal/adapter/http/middleware"
+	"go-gin-hexagonal/internal/adapter/storage"
+	"go-gin-hexagonal/pkg/config"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/csrf"
 )
 
 type Router struct {
-	authHandler    *handlers.AuthHandler
-	userHandler    *handlers.UserHandler
-	authMiddleware *middleware.AuthMiddleware
+	authHandler        *handlers.AuthHandler
+	userHandler        *handlers.UserHandler
+	avatarHandler      *handlers.AvatarHandler
+	auditHandler       *handlers.AuditHandler
+	importHandler      *handlers.ImportHandler
+	exportHandler      *handlers.ExportHandler
+	attributeHandler   *handlers.AttributeHandler
+	groupHandler       *handlers.GroupHandler
+	preferencesHandler *handlers.PreferencesHandler
+	authMiddleware     *middleware.AuthMiddleware
+	captchaMiddleware  *middleware.CaptchaMiddleware
+	groupMiddleware    *middleware.GroupMiddleware
+	cookieConfig       config.CookieConfig
+	storageConfig      config.StorageConfig
+	exportConfig       config.ExportConfig
 }
 
 func NewRouter(
 	authHandler *handlers.AuthHandler,
 	userHandler *handlers.UserHandler,
+	avatarHandler *handlers.AvatarHandler,
+	auditHandler *handlers.AuditHandler,
+	importHandler *handlers.ImportHandler,
+	exportHandler *handlers.ExportHandler,
+	attributeHandler *handlers.AttributeHandler,
+	groupHandler *handlers.GroupHandler,
+	preferencesHandler *handlers.PreferencesHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	captchaMiddleware *middleware.CaptchaMiddleware,
+	groupMiddleware *middleware.GroupMiddleware,
+	cookieConfig config.CookieConfig,
+	storageConfig config.StorageConfig,
+	exportConfig config.ExportConfig,
 ) *Router {
 	return &Router{
-		authHandler:    authHandler,
-		userHandler:    userHandler,
-		authMiddleware: authMiddleware,
+		authHandler:        authHandler,
+		userHandler:        userHandler,
+		avatarHandler:      avatarHandler,
+		auditHandler:       auditHandler,
+		importHandler:      importHandler,
+		exportHandler:      exportHandler,
+		attributeHandler:   attributeHandler,
+		groupHandler:       groupHandler,
+		preferencesHandler: preferencesHandler,
+		authMiddleware:     authMiddleware,
+		captchaMiddleware:  captchaMiddleware,
+		groupMiddleware:    groupMiddleware,
+		cookieConfig:       cookieConfig,
+		storageConfig:      storageConfig,
+		exportConfig:       exportConfig,
 	}
 }
 func (r *Router) SetupRoutes() *gin.Engine {
 	router := gin.Default()
 	router.Use(gin.Recovery())
 	router.Use(gin.Logger())
-	router.Use(middleware.CORSMiddleware())
-	router.Use(middleware.CSRFMiddleware())
+	router.Use(middleware.RequestContextMiddleware())
+	router.Use(middleware.CORSMiddleware(r.cookieConfig))
 
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -39,7 +77,11 @@ func (r *Router) SetupRoutes() *gin.Engine {
 		})
 	})
 
-	router.GET("/csrf-token", r.authMiddleware.Middleware(), func(c *gin.Context) {
+	csrfMiddleware := middleware.CSRFMiddleware(r.cookieConfig)
+
+	// Served without authentication: cookie clients need a CSRF token to
+	// refresh an access token that has already expired.
+	router.GET("/csrf-token", csrfMiddleware, func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status":  true,
 			"message": "CSRF token retrieved successfully",
@@ -47,9 +89,16 @@ func (r *Router) SetupRoutes() *gin.Engine {
 		})
 	})
 
+	if r.storageConfig.Driver == storage.DriverLocal {
+		router.Static(storage.LocalMountPath(r.storageConfig), r.storageConfig.LocalDir)
+	}
+
 	v1 := router.Group("/api/v1")
-	RegisterAuthRoutes(v1, r.authHandler, r.authMiddleware)
-	RegisterUserRoutes(v1, r.userHandler, r.authMiddleware)
+	RegisterAuthRoutes(v1, r.authHandler, r.authMiddleware, r.captchaMiddleware, csrfMiddleware)
+
+	protected := v1.Group("", csrfMiddleware)
+	RegisterUserRoutes(protected, r.userHandler, r.avatarHandler, r.groupHandler, r.preferencesHandler, r.authMiddleware)
+	RegisterAdminRoutes(protected, r.auditHandler, r.userHandler, r.importHandler, r.exportHandler, r.attributeHandler, r.groupHandler, r.authMiddleware, r.groupMiddleware, r.exportConfig)
 
 	return router
 }
@@ -3,20 +3,38 @@ package routes
 import (
 	"go-gin-hexagonal/internal/adapter/http/handlers"
 	"go-gin-hexagonal/internal/adapter/http/middleware"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterUserRoutes(rg *gin.RouterGroup, userHandler *handlers.UserHandler, authMiddleware *middleware.AuthMiddleware) {
+func RegisterUserRoutes(rg *gin.RouterGroup, userHandler *handlers.UserHandler, avatarHandler *handlers.AvatarHandler, groupHandler *handlers.GroupHandler, preferencesHandler *handlers.PreferencesHandler, authMiddleware *middleware.AuthMiddleware) {
 	users := rg.Group("/users")
-	users.Use(authMiddleware.Middleware())
+
+	// Restricted tokens issued while a password change is pending can only
+	// reach this endpoint.
+	users.PUT("/change-password", authMiddleware.Middleware(ports.TokenScopePasswordChange), userHandler.ChangePassword)
+
+	usersProtected := users.Group("")
+	usersProtected.Use(authMiddleware.Middleware())
 	{
-		users.GET("", userHandler.GetAllUsers)
-		users.GET("/profile", userHandler.GetProfile)
-		users.GET("/:id", userHandler.GetUserByID)
-		users.POST("", userHandler.CreateUser)
-		users.PUT("/profile", userHandler.UpdateProfile)
-		users.PUT("/change-password", userHandler.ChangePassword)
-		users.DELETE("/:id", userHandler.DeleteUser)
+		usersProtected.GET("", userHandler.GetAllUsers)
+		usersProtected.GET("/profile", userHandler.GetProfile)
+		usersProtected.GET("/profile/groups", groupHandler.ListProfileGroups)
+		usersProtected.GET("/profile/preferences", preferencesHandler.GetPreferences)
+		usersProtected.GET("/username/:username", userHandler.GetUserByUsername)
+		usersProtected.GET("/:id", userHandler.GetUserByID)
+		usersProtected.POST("", authMiddleware.RequireRole(entity.RoleAdmin), userHandler.CreateUser)
+		usersProtected.PUT("/profile", userHandler.UpdateProfile)
+		usersProtected.PATCH("/profile", userHandler.PatchProfile)
+		usersProtected.PUT("/profile/preferences", preferencesHandler.UpdatePreferences)
+		usersProtected.PUT("/profile/avatar", avatarHandler.UploadAvatar)
+		usersProtected.DELETE("/profile/avatar", avatarHandler.DeleteAvatar)
+		usersProtected.PUT("/:id", authMiddleware.RequireRole(entity.RoleAdmin), userHandler.UpdateUser)
+		usersProtected.PATCH("/:id", authMiddleware.RequireRole(entity.RoleAdmin), userHandler.PatchUser)
+		usersProtected.DELETE("/:id", authMiddleware.RequireRole(entity.RoleAdmin), userHandler.DeleteUser)
+		usersProtected.POST("/:id/invitation", authMiddleware.RequireRole(entity.RoleAdmin), userHandler.ResendInvitation)
+		usersProtected.DELETE("/:id/invitation", authMiddleware.RequireRole(entity.RoleAdmin), userHandler.RevokeInvitation)
 	}
 }
@@ -23,8 +23,8 @@ func NewSMTPMailer(cfg *config.MailerConfig) ports.MailerManager {
 	}
 }
 
-func (m *SMTPMailer) LoadEmailTemplate(templateName string, data any) (string, error) {
-	templatePath := filepath.Join("internal", "adapter", "mailer", "template", templateName+".html")
+func (m *SMTPMailer) LoadEmailTemplate(templateName string, locale string, data any) (string, error) {
+	templatePath := findTemplate(templateName, locale)
 
 	// Check if template file exists
 	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
@@ -47,6 +47,21 @@ func (m *SMTPMailer) LoadEmailTemplate(templateName string, data any) (string, e
 	return renderedBody, nil
 }
 
+// findTemplate prefers the translation in template/<locale> and falls back
+// to the default English template.
+func findTemplate(templateName string, locale string) string {
+	dir := filepath.Join("internal", "adapter", "mailer", "template")
+	file := templateName + ".html"
+
+	if locale != "" {
+		localized := filepath.Join(dir, filepath.Base(locale), file)
+		if _, err := os.Stat(localized); err == nil {
+			return localized
+		}
+	}
+	return filepath.Join(dir, file)
+}
+
 func (m *SMTPMailer) SendEmail(to string, subject string, body string) error {
 	mailer := gomail.NewMessage()
 	mailer.SetHeader("From", m.cfg.Sender)
@@ -29,12 +29,18 @@ func NewJWTToken(config config.JWTConfig) ports.TokenManager {
 }
 
 func (tm *JWTToken) GenerateAccessToken(user *entity.User) (string, time.Time, error) {
+	return tm.GenerateScopedAccessToken(user, "")
+}
+
+func (tm *JWTToken) GenerateScopedAccessToken(user *entity.User, scope string) (string, time.Time, error) {
 	expiryDate := time.Now().Add(tm.accessTokenExpiry)
 	domainClaims := &ports.AccessTokenClaims{
 		UserID:    user.ID,
 		Email:     user.Email,
 		Username:  user.Username,
+		Role:      user.Role,
 		TokenType: "access",
+		Scope:     scope,
 		ExpiresAt: expiryDate,
 		IssuedAt:  time.Now(),
 		NotBefore: time.Now(),
@@ -46,6 +52,7 @@ func (tm *JWTToken) GenerateAccessToken(user *entity.User) (string, time.Time, e
 		"user_id":    domainClaims.UserID.String(),
 		"email":      domainClaims.Email,
 		"username":   domainClaims.Username,
+		"role":       domainClaims.Role,
 		"token_type": domainClaims.TokenType,
 		"expires_at": domainClaims.ExpiresAt.Unix(),
 		"issued_at":  domainClaims.IssuedAt.Unix(),
@@ -53,6 +60,9 @@ func (tm *JWTToken) GenerateAccessToken(user *entity.User) (string, time.Time, e
 		"issuer":     domainClaims.Issuer,
 		"subject":    domainClaims.Subject,
 	}
+	if domainClaims.Scope != "" {
+		claims["scope"] = domainClaims.Scope
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(tm.accessTokenSecret))
@@ -116,6 +126,8 @@ func (tm *JWTToken) ValidateAccessToken(tokenString string) (*ports.AccessTokenC
 
 		email, _ := claims["email"].(string)
 		username, _ := claims["username"].(string)
+		role, _ := claims["role"].(string)
+		scope, _ := claims["scope"].(string)
 		issuer, _ := claims["issuer"].(string)
 		subject, _ := claims["subject"].(string)
 
@@ -127,7 +139,9 @@ func (tm *JWTToken) ValidateAccessToken(tokenString string) (*ports.AccessTokenC
 			UserID:    userID,
 			Email:     email,
 			Username:  username,
+			Role:      role,
 			TokenType: tokenType,
+			Scope:     scope,
 			ExpiresAt: expiresAt,
 			IssuedAt:  issuedAt,
 			NotBefore: notBefore,
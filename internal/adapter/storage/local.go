@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+)
+
+// DefaultLocalPublicURL is used when no public URL is configured.
+const DefaultLocalPublicURL = "/uploads"
+
+// LocalStorage keeps objects on disk under a root directory; the API serves
+// them statically from the path of the public URL.
+type LocalStorage struct {
+	root      string
+	publicURL string
+}
+
+func NewLocalStorage(cfg config.StorageConfig) ports.FileStorage {
+	return &LocalStorage{
+		root:      cfg.LocalDir,
+		publicURL: localPublicURL(cfg),
+	}
+}
+
+// LocalMountPath returns the route the local files should be served from.
+func LocalMountPath(cfg config.StorageConfig) string {
+	publicURL, err := url.Parse(localPublicURL(cfg))
+	if err != nil || publicURL.Path == "" {
+		return DefaultLocalPublicURL
+	}
+	return publicURL.Path
+}
+
+func localPublicURL(cfg config.StorageConfig) string {
+	if cfg.PublicURL == "" {
+		return DefaultLocalPublicURL
+	}
+	return cfg.PublicURL
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial object.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return joinURL(s.publicURL, key)
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid storage key: %s", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// NewFileStorage builds the storage adapter for the configured driver.
+func NewFileStorage(cfg config.StorageConfig) (ports.FileStorage, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case DriverLocal:
+		return NewLocalStorage(cfg), nil
+	case DriverS3:
+		return NewS3Storage(cfg, nil)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Driver)
+	}
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimSuffix(base, "/") + "/" + key
+}
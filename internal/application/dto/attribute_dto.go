@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttributeDefinitionInfo struct {
+	ID        uuid.UUID `json:"id"`
+	Key       string    `json:"key"`
+	Label     string    `json:"label"`
+	Type      string    `json:"type"`
+	Required  bool      `json:"required"`
+	Unique    bool      `json:"unique"`
+	Pattern   string    `json:"pattern,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateAttributeDefinitionRequest struct {
+	Key      string `json:"key" binding:"required,max=50"`
+	Label    string `json:"label" binding:"required,max=100"`
+	Type     string `json:"type" binding:"required,oneof=string number boolean date"`
+	Required bool   `json:"required"`
+	Unique   bool   `json:"unique"`
+	Pattern  string `json:"pattern,omitempty" binding:"max=255"`
+}
+
+type UpdateAttributeDefinitionRequest struct {
+	Label    *string `json:"label,omitempty" binding:"omitempty,min=1,max=100"`
+	Required *bool   `json:"required,omitempty"`
+	Unique   *bool   `json:"unique,omitempty"`
+	// Pattern replaces the current pattern; an empty string removes it.
+	Pattern *string `json:"pattern,omitempty" binding:"omitempty,max=255"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ListAuditEventsRequest struct {
+	Page      int        `form:"page,default=1" binding:"min=1"`
+	PageSize  int        `form:"page_size,default=20" binding:"min=1,max=100"`
+	ActorID   string     `form:"actor_id" binding:"omitempty,uuid"`
+	TargetID  string     `form:"target_id" binding:"omitempty,uuid"`
+	EventType string     `form:"event_type" example:"auth.login"`
+	Outcome   string     `form:"outcome" binding:"omitempty,oneof=success failure"`
+	From      *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type AuditEventInfo struct {
+	ID        uuid.UUID  `json:"id"`
+	ActorID   *uuid.UUID `json:"actor_id"`
+	TargetID  *uuid.UUID `json:"target_id"`
+	EventType string     `json:"event_type"`
+	Outcome   string     `json:"outcome"`
+	Reason    string     `json:"reason,omitempty"`
+	IPAddress string     `json:"ip_address"`
+	UserAgent string     `json:"user_agent"`
+	RequestID string     `json:"request_id"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -13,23 +13,29 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken            string `json:"access_token,omitempty"`
+	RefreshToken           string `json:"refresh_token,omitempty"`
+	PasswordChangeRequired bool   `json:"password_change_required"`
 }
 
 type RefreshTokenRequest struct {
-	RefreshToken string `json:"refresh_token" binding:"required"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 type RefreshTokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken            string `json:"access_token,omitempty"`
+	RefreshToken           string `json:"refresh_token,omitempty"`
+	PasswordChangeRequired bool   `json:"password_change_required"`
 }
 
 type VerifyEmailRequest struct {
 	Token string `json:"token" binding:"required"`
 }
 
+type SecureAccountRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 type SendVerifyEmailRequest struct {
 	Email string `json:"email" binding:"required,email" example:"email@example.com"`
 }
@@ -43,3 +49,9 @@ type ResetPasswordRequest struct {
 type SendResetPasswordRequest struct {
 	Email string `json:"email" binding:"required,email" example:"email@example.com"`
 }
+
+type AcceptInvitationRequest struct {
+	Token           string `json:"token" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6" example:"newpassword123"`
+	ConfirmPassword string `json:"confirm_password" binding:"required,eqfield=NewPassword" example:"newpassword123"`
+}
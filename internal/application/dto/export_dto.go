@@ -0,0 +1,7 @@
+package dto
+
+type ExportUsersRequest struct {
+	// Pagination parameters are accepted but ignored; every match is exported.
+	ListUsersRequest
+	Format string `form:"format,default=csv" binding:"oneof=csv ndjson xlsx"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GroupInfo struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type CreateGroupRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	ParentID    string `json:"parent_id,omitempty" binding:"omitempty,uuid"`
+}
+
+type UpdateGroupRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	// ParentID moves the group below another one; an empty string moves it
+	// to the top level.
+	ParentID *string `json:"parent_id,omitempty" binding:"omitempty,uuid|len=0"`
+}
+
+type ListGroupMembersRequest struct {
+	Page     int  `form:"page,default=1" binding:"min=1"`
+	PageSize int  `form:"page_size,default=10" binding:"min=1,max=100"`
+	Nested   bool `form:"nested"`
+}
+
+type AddGroupMemberRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StartImportRequest struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+	// Format defaults to the uploaded file's extension.
+	Format          string `form:"format" binding:"omitempty,oneof=csv json"`
+	DryRun          bool   `form:"dry_run"`
+	SendInvitations bool   `form:"send_invitations"`
+}
+
+type GetImportRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=50" binding:"min=1,max=500"`
+}
+
+type ImportJobInfo struct {
+	ID              uuid.UUID  `json:"id"`
+	CreatedBy       *uuid.UUID `json:"created_by"`
+	Format          string     `json:"format"`
+	DryRun          bool       `json:"dry_run"`
+	SendInvitations bool       `json:"send_invitations"`
+	Status          string     `json:"status"`
+	Total           int        `json:"total"`
+	Processed       int        `json:"processed"`
+	Succeeded       int        `json:"succeeded"`
+	Failed          int        `json:"failed"`
+	Error           string     `json:"error,omitempty"`
+	StartedAt       *time.Time `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type ImportRowInfo struct {
+	Row            int        `json:"row"`
+	Email          string     `json:"email"`
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+	InvitationSent bool       `json:"invitation_sent"`
+	Error          string     `json:"error,omitempty"`
+}
+
+type ImportJobDetail struct {
+	Job  *ImportJobInfo   `json:"job"`
+	Rows []*ImportRowInfo `json:"rows"`
+}
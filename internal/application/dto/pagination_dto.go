@@ -5,6 +5,13 @@ type (
 		Page     int    `form:"page,default=1" binding:"min=1"`
 		PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
 		Search   string `form:"search,omitempty"`
+		// Sort names a field the resource allows sorting on; each listing
+		// validates it against its own whitelist.
+		Sort  string `form:"sort,omitempty"`
+		Order string `form:"order,omitempty" binding:"omitempty,oneof=asc desc"`
+		// Cursor switches to keyset pagination; pass it empty for the first
+		// page and then the next or previous cursor from the response meta.
+		Cursor *string `form:"cursor,omitempty"`
 	}
 
 	PaginationResponse[T any] struct {
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type PreferencesInfo struct {
+	Locale             string          `json:"locale"`
+	Timezone           string          `json:"timezone"`
+	DateFormat         string          `json:"date_format"`
+	EmailNotifications map[string]bool `json:"email_notifications"`
+	UpdatedAt          *time.Time      `json:"updated_at,omitempty"`
+}
+
+type UpdatePreferencesRequest struct {
+	Locale     *string `json:"locale,omitempty"`
+	Timezone   *string `json:"timezone,omitempty" binding:"omitempty,max=64"`
+	DateFormat *string `json:"date_format,omitempty"`
+	// EmailNotifications opts in or out of the given categories and leaves
+	// the others unchanged.
+	EmailNotifications map[string]bool `json:"email_notifications,omitempty"`
+}
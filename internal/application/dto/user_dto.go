@@ -7,26 +7,77 @@ import (
 )
 
 type UserInfo struct {
-	ID        uuid.UUID `json:"id"`
-	Email     string    `json:"email"`
-	Username  string    `json:"username"`
-	Name      string    `json:"name"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uuid.UUID         `json:"id"`
+	Email           string            `json:"email"`
+	Username        string            `json:"username"`
+	Name            string            `json:"name"`
+	Role            string            `json:"role"`
+	Status          string            `json:"status"`
+	StatusReason    string            `json:"status_reason,omitempty"`
+	StatusChangedAt *time.Time        `json:"status_changed_at,omitempty"`
+	SuspendedUntil  *time.Time        `json:"suspended_until,omitempty"`
+	ApprovalStatus  string            `json:"approval_status,omitempty"`
+	AvatarURLs      map[string]string `json:"avatar_urls,omitempty"`
+	Attributes      map[string]any    `json:"attributes,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	DeletedAt       *time.Time        `json:"deleted_at,omitempty"`
+	Version         int               `json:"version"`
+}
+
+type ListUsersRequest struct {
+	PaginationRequest
+	// IncludeDeleted is only honored for admins.
+	IncludeDeleted bool       `form:"include_deleted"`
+	Email          string     `form:"email" binding:"omitempty,email"`
+	Status         string     `form:"status" binding:"omitempty,oneof=pending_verification active suspended deactivated"`
+	IsActive       *bool      `form:"is_active"`
+	CreatedFrom    *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo      *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedFrom    *time.Time `form:"updated_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedTo      *time.Time `form:"updated_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Attributes come from attributes[key]=value query parameters, which
+	// form binding cannot map, so handlers fill them in.
+	Attributes map[string]string `form:"-"`
 }
 
 type CreateUserRequest struct {
-	Email string `json:"email" binding:"required,email"`
-	Name  string `json:"name" binding:"required,min=3,max=100"`
+	Email      string         `json:"email" binding:"required,email"`
+	Name       string         `json:"name" binding:"required,min=3,max=100"`
+	Attributes map[string]any `json:"attributes,omitempty"`
 }
 
 type UpdateUserRequest struct {
 	Name     *string `json:"name,omitempty" binding:"omitempty,min=3,max=100"`
 	Username *string `json:"username,omitempty" binding:"omitempty,min=3,max=50"`
+	// Attributes are merged into the current values; null removes one.
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// UserPatchDocument is the part of a user PATCH requests operate on. A
+// patched document must still decode into it, so patches cannot add fields,
+// change types or clear required values.
+type UserPatchDocument struct {
+	Name       string         `json:"name" binding:"required,min=3,max=100"`
+	Username   string         `json:"username" binding:"required,min=3,max=50"`
+	Attributes map[string]any `json:"attributes"`
 }
 
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password" binding:"required"`
 	NewPassword     string `json:"new_password" binding:"required,min=6"`
 }
+
+type RejectUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type DeactivateUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+	// Until is an RFC 3339 timestamp; omit it to suspend indefinitely.
+	Until *time.Time `json:"until,omitempty"`
+}
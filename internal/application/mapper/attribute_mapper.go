@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/domain/ports/services"
+)
+
+func MapAttributeDefinitionInfoToDTO(definition *services.AttributeDefinitionInfo) *dto.AttributeDefinitionInfo {
+	return &dto.AttributeDefinitionInfo{
+		ID:        definition.ID,
+		Key:       definition.Key,
+		Label:     definition.Label,
+		Type:      definition.Type,
+		Required:  definition.Required,
+		Unique:    definition.Unique,
+		Pattern:   definition.Pattern,
+		CreatedAt: definition.CreatedAt,
+		UpdatedAt: definition.UpdatedAt,
+	}
+}
+
+func MapAttributeDefinitionInfosToDTO(definitions []*services.AttributeDefinitionInfo) []*dto.AttributeDefinitionInfo {
+	result := make([]*dto.AttributeDefinitionInfo, 0, len(definitions))
+	for _, definition := range definitions {
+		result = append(result, MapAttributeDefinitionInfoToDTO(definition))
+	}
+	return result
+}
+
+func MapCreateAttributeDefinitionRequestToService(req *dto.CreateAttributeDefinitionRequest) *services.CreateAttributeDefinitionRequest {
+	return &services.CreateAttributeDefinitionRequest{
+		Key:      req.Key,
+		Label:    req.Label,
+		Type:     req.Type,
+		Required: req.Required,
+		Unique:   req.Unique,
+		Pattern:  req.Pattern,
+	}
+}
+
+func MapUpdateAttributeDefinitionRequestToService(req *dto.UpdateAttributeDefinitionRequest) *services.UpdateAttributeDefinitionRequest {
+	return &services.UpdateAttributeDefinitionRequest{
+		Label:    req.Label,
+		Required: req.Required,
+		Unique:   req.Unique,
+		Pattern:  req.Pattern,
+	}
+}
@@ -0,0 +1,57 @@
+package mapper
+
+import (
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/domain/ports/services"
+
+	"github.com/google/uuid"
+)
+
+func MapListAuditEventsRequestToService(req *dto.ListAuditEventsRequest) *services.ListAuditEventsRequest {
+	return &services.ListAuditEventsRequest{
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		ActorID:   parseOptionalUUID(req.ActorID),
+		TargetID:  parseOptionalUUID(req.TargetID),
+		EventType: req.EventType,
+		Outcome:   req.Outcome,
+		From:      req.From,
+		To:        req.To,
+	}
+}
+
+func MapAuditEventInfoToDTO(event *services.AuditEventInfo) *dto.AuditEventInfo {
+	return &dto.AuditEventInfo{
+		ID:        event.ID,
+		ActorID:   event.ActorID,
+		TargetID:  event.TargetID,
+		EventType: event.EventType,
+		Outcome:   event.Outcome,
+		Reason:    event.Reason,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		RequestID: event.RequestID,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func MapAuditEventInfosToDTO(events []*services.AuditEventInfo) []*dto.AuditEventInfo {
+	result := make([]*dto.AuditEventInfo, 0, len(events))
+	for _, event := range events {
+		result = append(result, MapAuditEventInfoToDTO(event))
+	}
+	return result
+}
+
+// parseOptionalUUID expects input that was already validated by the binding.
+func parseOptionalUUID(s string) *uuid.UUID {
+	if s == "" {
+		return nil
+	}
+
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
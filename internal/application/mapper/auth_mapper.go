@@ -14,8 +14,9 @@ func MapLoginRequestDTOToService(req *dto.LoginRequest) *services.LoginRequest {
 
 func MapLoginResponseServiceToDTO(res *services.LoginResponse) *dto.LoginResponse {
 	return &dto.LoginResponse{
-		AccessToken:  res.AccessToken,
-		RefreshToken: res.RefreshToken,
+		AccessToken:            res.AccessToken,
+		RefreshToken:           res.RefreshToken,
+		PasswordChangeRequired: res.PasswordChangeRequired,
 	}
 }
 
@@ -28,10 +29,17 @@ func MapRegisterRequestDTOToService(req *dto.RegisterRequest) *services.Register
 	}
 }
 
+func MapRefreshTokenRequestDTOToService(req *dto.RefreshTokenRequest) *services.RefreshTokenRequest {
+	return &services.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+	}
+}
+
 func MapRefreshTokenResponseServiceToDTO(res *services.RefreshTokenResponse) *dto.RefreshTokenResponse {
 	return &dto.RefreshTokenResponse{
-		AccessToken:  res.AccessToken,
-		RefreshToken: res.RefreshToken,
+		AccessToken:            res.AccessToken,
+		RefreshToken:           res.RefreshToken,
+		PasswordChangeRequired: res.PasswordChangeRequired,
 	}
 }
 
@@ -42,3 +50,11 @@ func MapResetPasswordRequestDTOToService(req *dto.ResetPasswordRequest) *service
 		ConfirmPassword: req.ConfirmPassword,
 	}
 }
+
+func MapAcceptInvitationRequestDTOToService(req *dto.AcceptInvitationRequest) *services.AcceptInvitationRequest {
+	return &services.AcceptInvitationRequest{
+		Token:           req.Token,
+		NewPassword:     req.NewPassword,
+		ConfirmPassword: req.ConfirmPassword,
+	}
+}
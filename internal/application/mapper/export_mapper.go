@@ -0,0 +1,13 @@
+package mapper
+
+import (
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/domain/ports/services"
+)
+
+func MapExportUsersRequestToService(req *dto.ExportUsersRequest) *services.ExportUsersRequest {
+	return &services.ExportUsersRequest{
+		Format:           req.Format,
+		ListUsersRequest: *MapListUsersRequestToService(&req.ListUsersRequest),
+	}
+}
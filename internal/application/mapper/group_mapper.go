@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/domain/ports/services"
+
+	"github.com/google/uuid"
+)
+
+func MapGroupInfoToDTO(group *services.GroupInfo) *dto.GroupInfo {
+	return &dto.GroupInfo{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		ParentID:    group.ParentID,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
+
+func MapGroupInfosToDTO(groups []*services.GroupInfo) []*dto.GroupInfo {
+	result := make([]*dto.GroupInfo, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, MapGroupInfoToDTO(group))
+	}
+	return result
+}
+
+func MapCreateGroupRequestToService(req *dto.CreateGroupRequest) *services.CreateGroupRequest {
+	return &services.CreateGroupRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    parseOptionalUUID(req.ParentID),
+	}
+}
+
+func MapUpdateGroupRequestToService(req *dto.UpdateGroupRequest) *services.UpdateGroupRequest {
+	result := &services.UpdateGroupRequest{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if req.ParentID != nil {
+		result.ParentID = parseOptionalUUID(*req.ParentID)
+		if result.ParentID == nil {
+			topLevel := uuid.Nil
+			result.ParentID = &topLevel
+		}
+	}
+	return result
+}
+
+func MapListGroupMembersRequestToService(req *dto.ListGroupMembersRequest) *services.ListGroupMembersRequest {
+	return &services.ListGroupMembersRequest{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Nested:   req.Nested,
+	}
+}
@@ -0,0 +1,66 @@
+package mapper
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/domain/ports/services"
+
+	"github.com/google/uuid"
+)
+
+func MapStartImportRequestToService(req *dto.StartImportRequest, createdBy uuid.UUID, file io.Reader) *services.ImportRequest {
+	format := req.Format
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(filepath.Ext(req.File.Filename), "."))
+	}
+
+	return &services.ImportRequest{
+		CreatedBy:       &createdBy,
+		Format:          format,
+		File:            file,
+		DryRun:          req.DryRun,
+		SendInvitations: req.SendInvitations,
+	}
+}
+
+func MapImportJobInfoToDTO(job *services.ImportJobInfo) *dto.ImportJobInfo {
+	return &dto.ImportJobInfo{
+		ID:              job.ID,
+		CreatedBy:       job.CreatedBy,
+		Format:          job.Format,
+		DryRun:          job.DryRun,
+		SendInvitations: job.SendInvitations,
+		Status:          job.Status,
+		Total:           job.Total,
+		Processed:       job.Processed,
+		Succeeded:       job.Succeeded,
+		Failed:          job.Failed,
+		Error:           job.Error,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+		CreatedAt:       job.CreatedAt,
+	}
+}
+
+func MapImportJobDetailToDTO(detail *services.ImportJobDetail) *dto.ImportJobDetail {
+	rows := make([]*dto.ImportRowInfo, 0, len(detail.Rows))
+	for _, row := range detail.Rows {
+		rows = append(rows, &dto.ImportRowInfo{
+			Row:            row.Row,
+			Email:          row.Email,
+			Name:           row.Name,
+			Status:         row.Status,
+			UserID:         row.UserID,
+			InvitationSent: row.InvitationSent,
+			Error:          row.Error,
+		})
+	}
+
+	return &dto.ImportJobDetail{
+		Job:  MapImportJobInfoToDTO(detail.Job),
+		Rows: rows,
+	}
+}
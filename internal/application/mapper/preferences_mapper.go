@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	"go-gin-hexagonal/internal/application/dto"
+	"go-gin-hexagonal/internal/domain/ports/services"
+)
+
+func MapPreferencesInfoToDTO(preferences *services.PreferencesInfo) *dto.PreferencesInfo {
+	result := &dto.PreferencesInfo{
+		Locale:             preferences.Locale,
+		Timezone:           preferences.Timezone,
+		DateFormat:         preferences.DateFormat,
+		EmailNotifications: preferences.EmailNotifications,
+	}
+	// Defaults that were never saved have no update time.
+	if !preferences.UpdatedAt.IsZero() {
+		result.UpdatedAt = &preferences.UpdatedAt
+	}
+	return result
+}
+
+func MapUpdatePreferencesRequestToService(req *dto.UpdatePreferencesRequest) *services.UpdatePreferencesRequest {
+	return &services.UpdatePreferencesRequest{
+		Locale:             req.Locale,
+		Timezone:           req.Timezone,
+		DateFormat:         req.DateFormat,
+		EmailNotifications: req.EmailNotifications,
+	}
+}
@@ -1,18 +1,30 @@
 package mapper
 
 import (
+	"reflect"
+
 	"go-gin-hexagonal/internal/application/dto"
 	"go-gin-hexagonal/internal/domain/ports/services"
 )
 
 func MapUserInfoToDTO(user *services.UserInfo) *dto.UserInfo {
 	return &dto.UserInfo{
-		ID:        user.ID,
-		Email:     user.Email,
-		Username:  user.Username,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              user.ID,
+		Email:           user.Email,
+		Username:        user.Username,
+		Name:            user.Name,
+		Role:            user.Role,
+		Status:          user.Status,
+		StatusReason:    user.StatusReason,
+		StatusChangedAt: user.StatusChangedAt,
+		SuspendedUntil:  user.SuspendedUntil,
+		ApprovalStatus:  user.ApprovalStatus,
+		AvatarURLs:      user.AvatarURLs,
+		Attributes:      user.Attributes,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		DeletedAt:       user.DeletedAt,
+		Version:         user.Version,
 	}
 }
 
@@ -26,17 +38,97 @@ func MapUserPaginationResponseToDTO(response *services.UserPaginationResponse) *
 	}
 }
 
+func MapListUsersRequestToService(req *dto.ListUsersRequest) *services.ListUsersRequest {
+	return &services.ListUsersRequest{
+		Page:           req.Page,
+		PageSize:       req.PageSize,
+		Cursor:         req.Cursor,
+		IncludeDeleted: req.IncludeDeleted,
+		Search:         req.Search,
+		Sort:           req.Sort,
+		Order:          req.Order,
+		Email:          req.Email,
+		Status:         req.Status,
+		IsActive:       req.IsActive,
+		CreatedFrom:    req.CreatedFrom,
+		CreatedTo:      req.CreatedTo,
+		UpdatedFrom:    req.UpdatedFrom,
+		UpdatedTo:      req.UpdatedTo,
+		Attributes:     req.Attributes,
+	}
+}
+
 func MapCreateUserRequestToService(req *dto.CreateUserRequest) *services.CreateUserRequest {
 	return &services.CreateUserRequest{
-		Email: req.Email,
-		Name:  req.Name,
+		Email:      req.Email,
+		Name:       req.Name,
+		Attributes: req.Attributes,
 	}
 }
 
 func MapUpdateUserRequestToService(req *dto.UpdateUserRequest) *services.UpdateUserRequest {
 	return &services.UpdateUserRequest{
-		Name:     req.Name,
-		Username: req.Username,
+		Name:       req.Name,
+		Username:   req.Username,
+		Attributes: req.Attributes,
+	}
+}
+
+func MapUserInfoToPatchDocument(user *services.UserInfo) *dto.UserPatchDocument {
+	attributes := make(map[string]any, len(user.Attributes))
+	for key, value := range user.Attributes {
+		attributes[key] = value
+	}
+
+	return &dto.UserPatchDocument{
+		Name:       user.Name,
+		Username:   user.Username,
+		Attributes: attributes,
+	}
+}
+
+// MapUserPatchToService builds an update holding only what a patch changed
+// between the original and patched documents, with removed attributes set to
+// nil. It returns nil when nothing changed.
+func MapUserPatchToService(original, patched *dto.UserPatchDocument) *services.UpdateUserRequest {
+	req := &services.UpdateUserRequest{}
+	changed := false
+
+	if patched.Name != original.Name {
+		req.Name = &patched.Name
+		changed = true
+	}
+	if patched.Username != original.Username {
+		req.Username = &patched.Username
+		changed = true
+	}
+
+	attributes := make(map[string]any)
+	for key := range original.Attributes {
+		if _, ok := patched.Attributes[key]; !ok {
+			attributes[key] = nil
+		}
+	}
+	for key, value := range patched.Attributes {
+		if current, ok := original.Attributes[key]; !ok || !reflect.DeepEqual(current, value) {
+			attributes[key] = value
+		}
+	}
+	if len(attributes) > 0 {
+		req.Attributes = attributes
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return req
+}
+
+func MapSuspendUserRequestToService(req *dto.SuspendUserRequest) *services.SuspendUserRequest {
+	return &services.SuspendUserRequest{
+		Reason: req.Reason,
+		Until:  req.Until,
 	}
 }
 
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// attributeDateLayout is the format of date attribute values.
+const attributeDateLayout = "2006-01-02"
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+type AttributeService struct {
+	attributeRepo repositories.AttributeDefinitionRepository
+	userRepo      repositories.UserRepository
+}
+
+func NewAttributeService(attributeRepo repositories.AttributeDefinitionRepository, userRepo repositories.UserRepository) services.AttributeService {
+	return &AttributeService{
+		attributeRepo: attributeRepo,
+		userRepo:      userRepo,
+	}
+}
+
+func FormatAttributeDefinitionInfo(definition *entity.AttributeDefinition) *services.AttributeDefinitionInfo {
+	return &services.AttributeDefinitionInfo{
+		ID:        definition.ID,
+		Key:       definition.Key,
+		Label:     definition.Label,
+		Type:      definition.Type,
+		Required:  definition.Required,
+		Unique:    definition.Unique,
+		Pattern:   definition.Pattern,
+		CreatedAt: definition.CreatedAt,
+		UpdatedAt: definition.UpdatedAt,
+	}
+}
+
+func (s *AttributeService) ListDefinitions(ctx context.Context) ([]*services.AttributeDefinitionInfo, error) {
+	definitions, err := s.attributeRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*services.AttributeDefinitionInfo, 0, len(definitions))
+	for _, definition := range definitions {
+		infos = append(infos, FormatAttributeDefinitionInfo(definition))
+	}
+	return infos, nil
+}
+
+func (s *AttributeService) CreateDefinition(ctx context.Context, req *services.CreateAttributeDefinitionRequest) (*services.AttributeDefinitionInfo, error) {
+	if !attributeKeyPattern.MatchString(req.Key) {
+		return nil, errors.ErrInvalidAttributeKey
+	}
+	if !slices.Contains(entity.AttributeTypes, req.Type) {
+		return nil, errors.ErrInvalidAttributeType
+	}
+	if err := validateAttributePattern(req.Type, req.Pattern); err != nil {
+		return nil, err
+	}
+
+	if existing, _ := s.attributeRepo.FindByKey(ctx, req.Key); existing != nil {
+		return nil, errors.ErrAttributeDefinitionExists
+	}
+
+	definition, err := s.attributeRepo.Create(ctx, &entity.AttributeDefinition{
+		Key:      req.Key,
+		Label:    req.Label,
+		Type:     req.Type,
+		Required: req.Required,
+		Unique:   req.Unique,
+		Pattern:  req.Pattern,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return FormatAttributeDefinitionInfo(definition), nil
+}
+
+func (s *AttributeService) UpdateDefinition(ctx context.Context, id uuid.UUID, req *services.UpdateAttributeDefinitionRequest) (*services.AttributeDefinitionInfo, error) {
+	definition, err := s.attributeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.ErrAttributeDefinitionNotFound
+	}
+
+	if req.Label != nil {
+		definition.Label = *req.Label
+	}
+	if req.Required != nil {
+		definition.Required = *req.Required
+	}
+	if req.Pattern != nil {
+		if err := validateAttributePattern(definition.Type, *req.Pattern); err != nil {
+			return nil, err
+		}
+		definition.Pattern = *req.Pattern
+	}
+	if req.Unique != nil {
+		// Values stored while the attribute was not unique may clash.
+		if *req.Unique && !definition.Unique {
+			duplicated, err := s.userRepo.HasDuplicateAttribute(ctx, definition.Key)
+			if err != nil {
+				return nil, err
+			}
+			if duplicated {
+				return nil, errors.ErrAttributeHasDuplicates
+			}
+		}
+		definition.Unique = *req.Unique
+	}
+
+	updated, err := s.attributeRepo.Update(ctx, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return FormatAttributeDefinitionInfo(updated), nil
+}
+
+func (s *AttributeService) DeleteDefinition(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.attributeRepo.FindByID(ctx, id); err != nil {
+		return errors.ErrAttributeDefinitionNotFound
+	}
+
+	return s.attributeRepo.Delete(ctx, id)
+}
+
+func validateAttributePattern(attributeType, pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	if attributeType != entity.AttributeTypeString {
+		return errors.ErrInvalidAttributePattern
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return errors.ErrInvalidAttributePattern
+	}
+	return nil
+}
+
+// resolveAttributes applies changes to current and validates the result
+// against the attribute definitions. A nil value removes the attribute.
+// Required attributes must be set on new users and cannot be removed later;
+// users created before an attribute became required keep working without it.
+func resolveAttributes(
+	ctx context.Context,
+	attributeRepo repositories.AttributeDefinitionRepository,
+	userRepo repositories.UserRepository,
+	userID uuid.UUID,
+	current entity.UserAttributes,
+	changes map[string]any,
+	isNew bool,
+) (entity.UserAttributes, error) {
+	definitions, err := attributeRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*entity.AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	attributes := make(entity.UserAttributes, len(current)+len(changes))
+	maps.Copy(attributes, current)
+
+	// Sorted so the reported error does not depend on map order.
+	for _, key := range slices.Sorted(maps.Keys(changes)) {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, &errors.AttributeError{Key: key, Err: errors.ErrUnknownAttribute}
+		}
+
+		if changes[key] == nil {
+			if definition.Required {
+				return nil, &errors.AttributeError{Key: key, Err: errors.ErrAttributeRequired}
+			}
+			delete(attributes, key)
+			continue
+		}
+
+		value, err := attributeValue(definition, changes[key])
+		if err != nil {
+			return nil, &errors.AttributeError{Key: key, Err: err}
+		}
+
+		if definition.Unique {
+			taken, err := userRepo.ExistsByAttribute(ctx, key, value, userID)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				return nil, &errors.AttributeError{Key: key, Err: errors.ErrAttributeNotUnique}
+			}
+		}
+
+		attributes[key] = value
+	}
+
+	if isNew {
+		for _, definition := range definitions {
+			if _, ok := attributes[definition.Key]; definition.Required && !ok {
+				return nil, &errors.AttributeError{Key: definition.Key, Err: errors.ErrAttributeRequired}
+			}
+		}
+	}
+
+	return attributes, nil
+}
+
+// attributeValue checks a decoded JSON value against its definition and
+// returns it in the form it is stored in.
+func attributeValue(definition *entity.AttributeDefinition, value any) (any, error) {
+	switch definition.Type {
+	case entity.AttributeTypeString:
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.ErrAttributeWrongType
+		}
+		if definition.Pattern != "" {
+			pattern, err := regexp.Compile(definition.Pattern)
+			if err != nil || !pattern.MatchString(text) {
+				return nil, errors.ErrAttributePatternMismatch
+			}
+		}
+		return text, nil
+	case entity.AttributeTypeNumber:
+		switch number := value.(type) {
+		case float64:
+			return number, nil
+		case int:
+			return float64(number), nil
+		case json.Number:
+			parsed, err := number.Float64()
+			if err != nil {
+				return nil, errors.ErrAttributeWrongType
+			}
+			return parsed, nil
+		}
+		return nil, errors.ErrAttributeWrongType
+	case entity.AttributeTypeBoolean:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, errors.ErrAttributeWrongType
+		}
+		return flag, nil
+	case entity.AttributeTypeDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.ErrAttributeWrongType
+		}
+		if _, err := time.Parse(attributeDateLayout, text); err != nil {
+			return nil, errors.ErrAttributeWrongType
+		}
+		return text, nil
+	}
+	return nil, errors.ErrAttributeWrongType
+}
+
+// attributeFilter turns query string values into typed values for
+// UserFilter.Attributes.
+func attributeFilter(ctx context.Context, attributeRepo repositories.AttributeDefinitionRepository, raw map[string]string) (map[string]any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	definitions, err := attributeRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*entity.AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	filter := make(map[string]any, len(raw))
+	for _, key := range slices.Sorted(maps.Keys(raw)) {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, &errors.AttributeError{Key: key, Err: errors.ErrUnknownAttribute}
+		}
+
+		value, err := parseAttributeText(definition, raw[key])
+		if err != nil {
+			return nil, &errors.AttributeError{Key: key, Err: err}
+		}
+
+		filter[key] = value
+	}
+
+	return filter, nil
+}
+
+// parseAttributeText converts a value given as text, such as a query string
+// or CSV cell, into the type of its definition.
+func parseAttributeText(definition *entity.AttributeDefinition, text string) (any, error) {
+	var value any = text
+	var err error
+	switch definition.Type {
+	case entity.AttributeTypeNumber:
+		value, err = strconv.ParseFloat(text, 64)
+	case entity.AttributeTypeBoolean:
+		value, err = strconv.ParseBool(text)
+	case entity.AttributeTypeDate:
+		_, err = time.Parse(attributeDateLayout, text)
+	}
+	if err != nil {
+		return nil, errors.ErrAttributeWrongType
+	}
+	return value, nil
+}
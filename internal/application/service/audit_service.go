@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/utils"
+)
+
+type AuditService struct {
+	auditEventRepo repositories.AuditEventRepository
+	auditConfig    config.AuditConfig
+}
+
+func NewAuditService(auditEventRepo repositories.AuditEventRepository, auditConfig config.AuditConfig) services.AuditService {
+	return &AuditService{
+		auditEventRepo: auditEventRepo,
+		auditConfig:    auditConfig,
+	}
+}
+
+func FormatAuditEventInfo(event *entity.AuditEvent) *services.AuditEventInfo {
+	return &services.AuditEventInfo{
+		ID:        event.ID,
+		ActorID:   event.ActorID,
+		TargetID:  event.TargetID,
+		EventType: event.EventType,
+		Outcome:   event.Outcome,
+		Reason:    event.Reason,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		RequestID: event.RequestID,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func (s *AuditService) Record(ctx context.Context, record *services.AuditRecord) {
+	metadata := utils.RequestMetadataFromContext(ctx)
+
+	event := &entity.AuditEvent{
+		ActorID:   record.ActorID,
+		TargetID:  record.TargetID,
+		EventType: record.EventType,
+		Outcome:   record.Outcome,
+		Reason:    record.Reason,
+		IPAddress: metadata.IPAddress,
+		UserAgent: metadata.UserAgent,
+		RequestID: metadata.RequestID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.auditEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record audit event %s: %v", record.EventType, err)
+	}
+}
+
+func (s *AuditService) ListEvents(ctx context.Context, req *services.ListAuditEventsRequest) (*services.AuditEventPaginationResponse, error) {
+	filter := &repositories.AuditEventFilter{
+		ActorID:   req.ActorID,
+		TargetID:  req.TargetID,
+		EventType: req.EventType,
+		Outcome:   req.Outcome,
+		From:      req.From,
+		To:        req.To,
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	events, total, err := s.auditEventRepo.FindAll(ctx, filter, req.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	eventInfos := make([]*services.AuditEventInfo, 0, len(events))
+	for _, event := range events {
+		eventInfos = append(eventInfos, FormatAuditEventInfo(event))
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
+
+	return &services.AuditEventPaginationResponse{
+		Datas:      eventInfos,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// PruneExpired deletes events older than the configured retention period.
+func (s *AuditService) PruneExpired(ctx context.Context) error {
+	if s.auditConfig.Retention <= 0 {
+		return nil
+	}
+
+	deleted, err := s.auditEventRepo.DeleteOlderThan(ctx, time.Now().Add(-s.auditConfig.Retention))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		log.Printf("pruned %d audit events", deleted)
+	}
+
+	return nil
+}
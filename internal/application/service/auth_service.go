@@ -26,6 +26,13 @@ type AuthService struct {
 	passwordHasher   ports.PasswordHasher
 	emailService     services.EmailService
 	aesEncryptor     ports.Encryptor
+	invitationRepo   repositories.InvitationRepository
+	passwordPolicy   config.PasswordPolicyConfig
+	knownDeviceRepo  repositories.KnownDeviceRepository
+	geoLocator       ports.GeoLocator
+	auditService     services.AuditService
+	registration     services.RegistrationPolicy
+	usernamePolicy   services.UsernamePolicy
 }
 
 func NewAuthService(
@@ -35,6 +42,13 @@ func NewAuthService(
 	passwordHasher ports.PasswordHasher,
 	emailService services.EmailService,
 	aesEncryptor ports.Encryptor,
+	invitationRepo repositories.InvitationRepository,
+	passwordPolicy config.PasswordPolicyConfig,
+	knownDeviceRepo repositories.KnownDeviceRepository,
+	geoLocator ports.GeoLocator,
+	auditService services.AuditService,
+	registration services.RegistrationPolicy,
+	usernamePolicy services.UsernamePolicy,
 ) services.AuthService {
 	return &AuthService{
 		userRepo:         userRepo,
@@ -43,9 +57,59 @@ func NewAuthService(
 		passwordHasher:   passwordHasher,
 		emailService:     emailService,
 		aesEncryptor:     aesEncryptor,
+		invitationRepo:   invitationRepo,
+		passwordPolicy:   passwordPolicy,
+		knownDeviceRepo:  knownDeviceRepo,
+		geoLocator:       geoLocator,
+		auditService:     auditService,
+		registration:     registration,
+		usernamePolicy:   usernamePolicy,
 	}
 }
 
+// Email tokens are bound to the flow they were issued for so that, for
+// example, a verification link cannot be replayed against the reset endpoint.
+const (
+	emailTokenVerifyEmail   = "verify-email"
+	emailTokenResetPassword = "reset-password"
+	emailTokenSecureAccount = "secure-account"
+
+	verifyEmailTokenExpiry   = 5 * time.Minute
+	resetPasswordTokenExpiry = 15 * time.Minute
+	secureAccountTokenExpiry = 7 * 24 * time.Hour
+)
+
+// generateEmailToken is shared with UserService, which sends the same links
+// when an admin acts on someone's behalf.
+func generateEmailToken(encryptor ports.Encryptor, purpose, email string, expiry time.Duration) (string, error) {
+	return encryptor.Encrypt(purpose + "_" + email + "_" + utils.AddToCurrentTime(expiry))
+}
+
+// parseEmailToken returns the email address a token was issued to. The email
+// sits between the purpose and the expiry, so it may itself contain "_".
+func (s *AuthService) parseEmailToken(token, purpose string) (string, error) {
+	plaintext, err := s.aesEncryptor.Decrypt(token)
+	if err != nil {
+		return "", err
+	}
+
+	first := strings.Index(plaintext, "_")
+	last := strings.LastIndex(plaintext, "_")
+	if first < 0 || first == last || plaintext[:first] != purpose {
+		return "", errors.ErrTokenInvalid
+	}
+
+	expiryTime, err := utils.ParseTime(plaintext[last+1:])
+	if err != nil {
+		return "", errors.ErrTokenInvalid
+	}
+	if time.Now().After(expiryTime) {
+		return "", errors.ErrTokenExpired
+	}
+
+	return plaintext[first+1 : last], nil
+}
+
 func getVerifyEmailURL(token string) string {
 	return fmt.Sprintf("%s/verify-email?token=%s", config.GetAppURL(), url.QueryEscape(token))
 }
@@ -54,21 +118,100 @@ func getResetPasswordURL(token string) string {
 	return fmt.Sprintf("%s/reset-password?token=%s", config.GetAppURL(), url.QueryEscape(token))
 }
 
-func (s *AuthService) Login(ctx context.Context, req *services.LoginRequest) (*services.LoginResponse, error) {
+func getSecureAccountURL(token string) string {
+	return fmt.Sprintf("%s/secure-account?token=%s", config.GetAppURL(), url.QueryEscape(token))
+}
+
+// clientFingerprint identifies the client software a refresh token or known
+// device belongs to. Only the User-Agent is hashed, which a thief can copy
+// along with the token, so binding refresh tokens to it is best effort: it
+// stops replays from another browser, not from an attacker imitating the
+// original one. The IP address is left out as it changes too often on mobile
+// networks.
+func clientFingerprint(client *services.ClientInfo) string {
+	return utils.HashSHA256(client.UserAgent)
+}
+
+// audit records an authentication event about userID, which is uuid.Nil when
+// the user could not be identified. The user only counts as the actor of
+// successful events since failed attempts may come from anyone.
+func (s *AuthService) audit(ctx context.Context, eventType string, userID uuid.UUID, err error) {
+	record := &services.AuditRecord{
+		EventType: eventType,
+		Outcome:   entity.AuditOutcomeSuccess,
+	}
+	if userID != uuid.Nil {
+		record.TargetID = &userID
+		if err == nil {
+			record.ActorID = &userID
+		}
+	}
+	if err != nil {
+		record.Outcome = entity.AuditOutcomeFailure
+		record.Reason = err.Error()
+	}
+
+	s.auditService.Record(ctx, record)
+}
+
+func (s *AuthService) Login(ctx context.Context, req *services.LoginRequest) (res *services.LoginResponse, err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventLogin, userID, err) }()
+
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, errors.ErrUserNotFound
 	}
+	userID = user.ID
 
-	if !user.IsActive {
-		return nil, errors.ErrUserNotVerified
+	// Expired suspensions are lifted on sign-in as well so users do not have
+	// to wait for the background job.
+	if now := time.Now(); user.SuspensionExpired(now) {
+		if err := user.TransitionTo(entity.UserStatusActive, "", now); err != nil {
+			return nil, err
+		}
+		if _, err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, errors.ErrUpdateUser
+		}
+	}
+
+	if err := user.StatusError(); err != nil {
+		if err == errors.ErrUserNotVerified {
+			if _, err := s.invitationRepo.FindPendingByUserID(ctx, user.ID); err == nil {
+				return nil, errors.ErrInvitationPending
+			}
+		}
+		return nil, err
 	}
 
 	if err := s.passwordHasher.Verify(user.Password, req.Password); err != nil {
 		return nil, errors.ErrPasswordMismatch
 	}
 
-	accessToken, _, err := s.tokenManager.GenerateAccessToken(user)
+	if user.FirstLoginPending {
+		if err := s.completeFirstLogin(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.recordSignIn(ctx, user, &req.Client); err != nil {
+		log.Printf("failed to record sign-in device: %v", err)
+	}
+
+	if user.RequiresPasswordChange(s.passwordPolicy.MaxAge, time.Now()) {
+		restrictedToken, restrictedTokenExpiry, err := s.tokenManager.GenerateScopedAccessToken(user, ports.TokenScopePasswordChange)
+		if err != nil {
+			return nil, err
+		}
+
+		return &services.LoginResponse{
+			AccessToken:            restrictedToken,
+			AccessTokenExpiresAt:   restrictedTokenExpiry,
+			PasswordChangeRequired: true,
+		}, nil
+	}
+
+	accessToken, accessTokenExpiry, err := s.tokenManager.GenerateAccessToken(user)
 	if err != nil {
 		return nil, err
 	}
@@ -79,25 +222,112 @@ func (s *AuthService) Login(ctx context.Context, req *services.LoginRequest) (*s
 	}
 
 	refreshTokenEntity := &entity.RefreshToken{
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: refreshTokenExpiry,
+		UserID:            user.ID,
+		Token:             refreshToken,
+		ExpiresAt:         refreshTokenExpiry,
+		IPAddress:         req.Client.IPAddress,
+		UserAgent:         req.Client.UserAgent,
+		ClientFingerprint: clientFingerprint(&req.Client),
 	}
 	if err := s.refreshTokenRepo.Save(ctx, refreshTokenEntity); err != nil {
 		return nil, err
 	}
 
 	return &services.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiry,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiry,
 	}, nil
 }
 
+// completeFirstLogin records the user's first sign-in. With
+// ChangeOnFirstLogin the user is also flagged to change their password, so
+// the requirement outlasts the first session until the password is changed.
+func (s *AuthService) completeFirstLogin(ctx context.Context, user *entity.User) error {
+	user.FirstLoginPending = false
+	if s.passwordPolicy.ChangeOnFirstLogin {
+		user.MustChangePassword = true
+	}
+
+	if _, err := s.userRepo.Update(ctx, user); err != nil {
+		return errors.ErrUpdateUser
+	}
+	return nil
+}
+
+// recordSignIn remembers the client a user signed in from and emails a new
+// sign-in notification when its device or IP address has not been seen for
+// this user before. The very first sign-in is recorded without a notification.
+func (s *AuthService) recordSignIn(ctx context.Context, user *entity.User, client *services.ClientInfo) error {
+	devices, err := s.knownDeviceRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	fingerprint := clientFingerprint(client)
+	knownDevice, knownIP := false, false
+	for _, device := range devices {
+		if device.Fingerprint == fingerprint && device.IPAddress == client.IPAddress {
+			device.LastSeenAt = now
+			_, err := s.knownDeviceRepo.Update(ctx, device)
+			return err
+		}
+		knownDevice = knownDevice || device.Fingerprint == fingerprint
+		knownIP = knownIP || device.IPAddress == client.IPAddress
+	}
+
+	device := &entity.KnownDevice{
+		UserID:      user.ID,
+		Fingerprint: fingerprint,
+		IPAddress:   client.IPAddress,
+		UserAgent:   client.UserAgent,
+		Location:    s.geoLocator.Locate(client.IPAddress),
+		LastSeenAt:  now,
+	}
+	if _, err := s.knownDeviceRepo.Create(ctx, device); err != nil {
+		return err
+	}
+
+	if len(devices) == 0 || (knownDevice && knownIP) {
+		return nil
+	}
+
+	token, err := generateEmailToken(s.aesEncryptor, emailTokenSecureAccount, user.Email, secureAccountTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	go func(email string, data *services.NewSignInEmailData) {
+		if err := s.emailService.SendNewSignInEmail(email, data); err != nil {
+			log.Printf("failed to send new sign-in email: %v", err)
+		}
+	}(user.Email, &services.NewSignInEmailData{
+		Name:             user.Name,
+		SignInTime:       now.Format(utils.TimeFormat),
+		IPAddress:        device.IPAddress,
+		UserAgent:        device.UserAgent,
+		Location:         device.Location,
+		SecureAccountURL: getSecureAccountURL(token),
+	})
+
+	return nil
+}
+
 func (s *AuthService) Register(ctx context.Context, req *services.RegisterRequest) error {
+	if err := s.registration.CheckEmail(req.Email); err != nil {
+		return err
+	}
+
 	if s.userRepo.ExistsByEmail(ctx, req.Email) {
 		return errors.ErrUserAlreadyExists
 	}
 
+	if s.usernamePolicy.IsReserved(req.Username) {
+		return errors.ErrUsernameReserved
+	}
+
 	if s.userRepo.ExistsByUsername(ctx, req.Username) {
 		return errors.ErrUserAlreadyExists
 	}
@@ -108,15 +338,16 @@ func (s *AuthService) Register(ctx context.Context, req *services.RegisterReques
 	}
 
 	user := &entity.User{
-		Email:    req.Email,
-		Username: req.Username,
-		Password: hashedPassword,
-		Name:     req.Name,
+		Email:             req.Email,
+		Username:          req.Username,
+		Name:              req.Name,
+		Role:              entity.RoleUser,
+		Status:            entity.UserStatusPendingVerification,
+		FirstLoginPending: true,
 	}
+	user.SetPassword(hashedPassword, time.Now())
 
-	plaintext := user.Email + "_" + utils.AddToCurrentTime(5*time.Minute)
-
-	token, err := s.aesEncryptor.Encrypt(plaintext)
+	token, err := generateEmailToken(s.aesEncryptor, emailTokenVerifyEmail, user.Email, verifyEmailTokenExpiry)
 	if err != nil {
 		return err
 	}
@@ -136,13 +367,30 @@ func (s *AuthService) Register(ctx context.Context, req *services.RegisterReques
 	return err
 }
 
-func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*services.RefreshTokenResponse, error) {
+func (s *AuthService) RefreshToken(ctx context.Context, req *services.RefreshTokenRequest) (res *services.RefreshTokenResponse, err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventTokenRefresh, userID, err) }()
+
+	refreshToken := req.RefreshToken
 	claims, err := s.tokenManager.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		return nil, errors.ErrTokenInvalid
 	}
+	userID = claims.UserID
 
-	if !s.refreshTokenRepo.IsTokenValid(ctx, refreshToken) {
+	storedToken, err := s.refreshTokenRepo.FindByToken(ctx, refreshToken)
+	if err != nil {
+		return nil, errors.ErrTokenInvalid
+	}
+
+	// The stored token must belong to the user named in its claims and be
+	// presented by the same client it was originally issued to, as far as
+	// clientFingerprint can tell. Tokens issued before fingerprints were
+	// recorded have none and are bound to the client that rotates them.
+	if storedToken.UserID != claims.UserID {
+		return nil, errors.ErrTokenInvalid
+	}
+	if storedToken.ClientFingerprint != "" && storedToken.ClientFingerprint != clientFingerprint(&req.Client) {
 		return nil, errors.ErrTokenInvalid
 	}
 
@@ -151,46 +399,100 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*s
 		return nil, errors.ErrUserNotFound
 	}
 
-	newAccessToken, _, err := s.tokenManager.GenerateAccessToken(user)
+	if err := user.StatusError(); err != nil {
+		return nil, err
+	}
+
+	// Spending the token is the step that decides between concurrent
+	// requests presenting it: only the one that revokes it may continue. The
+	// loser is treated as token reuse and ends every session of the user.
+	revoked, err := s.refreshTokenRepo.RevokeByToken(ctx, refreshToken)
 	if err != nil {
 		return nil, err
 	}
+	if revoked == 0 {
+		if err := s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		return nil, errors.ErrTokenInvalid
+	}
 
-	newRefreshToken, refreshTokenExpiry, err := s.tokenManager.GenerateRefreshToken(user.ID)
+	// A session must not outlive a password that has to be changed, so, like
+	// Login, only a restricted token is issued for the spent refresh token.
+	if user.RequiresPasswordChange(s.passwordPolicy.MaxAge, time.Now()) {
+		restrictedToken, restrictedTokenExpiry, err := s.tokenManager.GenerateScopedAccessToken(user, ports.TokenScopePasswordChange)
+		if err != nil {
+			return nil, err
+		}
+
+		return &services.RefreshTokenResponse{
+			AccessToken:            restrictedToken,
+			AccessTokenExpiresAt:   restrictedTokenExpiry,
+			PasswordChangeRequired: true,
+		}, nil
+	}
+
+	newAccessToken, accessTokenExpiry, err := s.tokenManager.GenerateAccessToken(user)
 	if err != nil {
 		return nil, err
 	}
 
-	s.refreshTokenRepo.RevokeByToken(ctx, refreshToken)
+	newRefreshToken, refreshTokenExpiry, err := s.tokenManager.GenerateRefreshToken(user.ID)
+	if err != nil {
+		return nil, err
+	}
 
 	newRefreshTokenEntity := &entity.RefreshToken{
-		UserID:    user.ID,
-		Token:     newRefreshToken,
-		ExpiresAt: refreshTokenExpiry,
+		UserID:            user.ID,
+		Token:             newRefreshToken,
+		ExpiresAt:         refreshTokenExpiry,
+		IPAddress:         req.Client.IPAddress,
+		UserAgent:         req.Client.UserAgent,
+		ClientFingerprint: clientFingerprint(&req.Client),
 	}
 	if err := s.refreshTokenRepo.Save(ctx, newRefreshTokenEntity); err != nil {
 		return nil, err
 	}
 
 	return &services.RefreshTokenResponse{
-		AccessToken:  newAccessToken,
-		RefreshToken: newRefreshToken,
+		AccessToken:           newAccessToken,
+		AccessTokenExpiresAt:  accessTokenExpiry,
+		RefreshToken:          newRefreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiry,
 	}, nil
 }
 
-func (s *AuthService) Logout(ctx context.Context, userID uuid.UUID) error {
+func (s *AuthService) Logout(ctx context.Context, userID uuid.UUID) (err error) {
+	defer func() { s.audit(ctx, entity.AuditEventLogout, userID, err) }()
+
 	return s.refreshTokenRepo.RevokeAllByUserID(ctx, userID)
 }
 
-func (s *AuthService) SendVerifyEmail(ctx context.Context, email string) error {
+func (s *AuthService) LogoutWithRefreshToken(ctx context.Context, refreshToken string) error {
+	claims, err := s.tokenManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return errors.ErrTokenInvalid
+	}
+
+	storedToken, err := s.refreshTokenRepo.FindByToken(ctx, refreshToken)
+	if err != nil || storedToken.UserID != claims.UserID {
+		return errors.ErrTokenInvalid
+	}
+
+	return s.Logout(ctx, storedToken.UserID)
+}
+
+func (s *AuthService) SendVerifyEmail(ctx context.Context, email string) (err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventVerificationRequested, userID, err) }()
+
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return errors.ErrUserNotFound
 	}
+	userID = user.ID
 
-	plaintext := user.Email + "_" + utils.AddToCurrentTime(5*time.Minute)
-
-	token, err := s.aesEncryptor.Encrypt(plaintext)
+	token, err := generateEmailToken(s.aesEncryptor, emailTokenVerifyEmail, user.Email, verifyEmailTokenExpiry)
 	if err != nil {
 		return err
 	}
@@ -208,33 +510,45 @@ func (s *AuthService) SendVerifyEmail(ctx context.Context, email string) error {
 	return nil
 }
 
-func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
-	token, err := s.aesEncryptor.Decrypt(token)
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) (err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventEmailVerified, userID, err) }()
+
+	email, err := s.parseEmailToken(token, emailTokenVerifyEmail)
 	if err != nil {
 		return err
 	}
 
-	tokenArr := strings.Split(token, "_")
-	if len(tokenArr) != 2 {
-		return errors.ErrTokenInvalid
-	}
-
-	email := tokenArr[0]
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return errors.ErrUserNotFound
 	}
+	userID = user.ID
 
-	expiryStr := tokenArr[1]
-	expiryTime, err := utils.ParseTime(expiryStr)
-	if err != nil {
-		return errors.ErrTokenInvalid
+	// Invited users activate their account by accepting the invitation,
+	// which is also where they choose their password.
+	if _, err := s.invitationRepo.FindPendingByUserID(ctx, user.ID); err == nil {
+		return errors.ErrInvitationPending
 	}
-	if time.Now().After(expiryTime) {
-		return errors.ErrTokenExpired
+
+	if user.Status != entity.UserStatusPendingVerification {
+		if err := user.StatusError(); err == errors.ErrRegistrationRejected {
+			return err
+		}
+		return nil
+	}
+
+	if user.ApprovalStatus == entity.ApprovalPending {
+		return nil
 	}
 
-	user.IsActive = true
+	if s.registration.RequiresApproval() {
+		// The email is verified, but the account stays pending until an
+		// admin approves it.
+		user.ApprovalStatus = entity.ApprovalPending
+	} else if err := user.TransitionTo(entity.UserStatusActive, "", time.Now()); err != nil {
+		return err
+	}
 
 	if _, err := s.userRepo.Update(ctx, user); err != nil {
 		return errors.ErrUpdateUser
@@ -243,15 +557,17 @@ func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
 	return nil
 }
 
-func (s *AuthService) SendResetPassword(ctx context.Context, email string) error {
+func (s *AuthService) SendResetPassword(ctx context.Context, email string) (err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventPasswordResetRequested, userID, err) }()
+
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return errors.ErrUserNotFound
 	}
+	userID = user.ID
 
-	plaintext := user.Email + "_" + utils.AddToCurrentTime(15*time.Minute)
-
-	token, err := s.aesEncryptor.Encrypt(plaintext)
+	token, err := generateEmailToken(s.aesEncryptor, emailTokenResetPassword, user.Email, resetPasswordTokenExpiry)
 	if err != nil {
 		return err
 	}
@@ -269,41 +585,112 @@ func (s *AuthService) SendResetPassword(ctx context.Context, email string) error
 	return nil
 }
 
-func (s *AuthService) ResetPassword(ctx context.Context, req *services.ResetPasswordRequest) error {
-	token := req.Token
-	token, err := s.aesEncryptor.Decrypt(token)
+func (s *AuthService) ResetPassword(ctx context.Context, req *services.ResetPasswordRequest) (err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventPasswordReset, userID, err) }()
+
+	email, err := s.parseEmailToken(req.Token, emailTokenResetPassword)
 	if err != nil {
 		return err
 	}
 
-	tokenArr := strings.Split(token, "_")
-	if len(tokenArr) != 2 {
-		return errors.ErrTokenInvalid
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return errors.ErrUserNotFound
+	}
+	userID = user.ID
+
+	hashedPassword, err := s.passwordHasher.Hash(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	user.SetPassword(hashedPassword, time.Now())
+	if _, err := s.userRepo.Update(ctx, user); err != nil {
+		return errors.ErrUpdateUser
+	}
+
+	return nil
+}
+
+// SecureAccount handles the "this wasn't me" link from a new sign-in email. It
+// signs the user out of every session, forgets their known devices and sends
+// a password reset link.
+func (s *AuthService) SecureAccount(ctx context.Context, token string) (err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventAccountSecured, userID, err) }()
+
+	email, err := s.parseEmailToken(token, emailTokenSecureAccount)
+	if err != nil {
+		return err
 	}
 
-	user, err := s.userRepo.FindByEmail(ctx, tokenArr[0])
+	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return errors.ErrUserNotFound
 	}
+	userID = user.ID
+
+	if err := s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+		return err
+	}
 
-	expiryStr := tokenArr[1]
-	expiryTime, err := utils.ParseTime(expiryStr)
+	if err := s.knownDeviceRepo.DeleteAllByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+
+	return s.SendResetPassword(ctx, user.Email)
+}
+
+func (s *AuthService) AcceptInvitation(ctx context.Context, req *services.AcceptInvitationRequest) (err error) {
+	var userID uuid.UUID
+	defer func() { s.audit(ctx, entity.AuditEventInvitationAccepted, userID, err) }()
+
+	invitation, err := s.invitationRepo.FindByTokenHash(ctx, utils.HashSHA256(req.Token))
 	if err != nil {
+		return errors.ErrInvitationNotFound
+	}
+	userID = invitation.UserID
+
+	if invitation.AcceptedAt != nil {
+		return errors.ErrInvitationAlreadyAccepted
+	}
+	if invitation.RevokedAt != nil {
 		return errors.ErrTokenInvalid
 	}
-	if time.Now().After(expiryTime) {
+	if time.Now().After(invitation.ExpiresAt) {
 		return errors.ErrTokenExpired
 	}
 
+	user, err := s.userRepo.FindByID(ctx, invitation.UserID)
+	if err != nil {
+		return errors.ErrUserNotFound
+	}
+
+	// An admin may have deactivated the invited user since the email went
+	// out; the invitation must not bring the account back.
+	if user.Status != entity.UserStatusPendingVerification {
+		return errors.ErrTokenInvalid
+	}
+
 	hashedPassword, err := s.passwordHasher.Hash(req.NewPassword)
 	if err != nil {
 		return err
 	}
 
-	user.Password = hashedPassword
+	user.SetPassword(hashedPassword, time.Now())
+	if err := user.TransitionTo(entity.UserStatusActive, "", time.Now()); err != nil {
+		return err
+	}
 	if _, err := s.userRepo.Update(ctx, user); err != nil {
 		return errors.ErrUpdateUser
 	}
 
+	now := time.Now()
+	invitation.AcceptedAt = &now
+	if _, err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return err
+	}
+
 	return nil
 }
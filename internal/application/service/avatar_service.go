@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+	"go-gin-hexagonal/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// avatarContentTypes are the sniffed types with a registered image decoder.
+var avatarContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type AvatarService struct {
+	userRepo     repositories.UserRepository
+	fileStorage  ports.FileStorage
+	avatarConfig config.AvatarConfig
+}
+
+func NewAvatarService(userRepo repositories.UserRepository, fileStorage ports.FileStorage, avatarConfig config.AvatarConfig) services.AvatarService {
+	return &AvatarService{
+		userRepo:     userRepo,
+		fileStorage:  fileStorage,
+		avatarConfig: avatarConfig,
+	}
+}
+
+func (s *AvatarService) UploadAvatar(ctx context.Context, userID uuid.UUID, file io.Reader) (*services.UserInfo, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	img, err := s.decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every upload gets a fresh, unguessable key so cached URLs of the old
+	// avatar never serve the new image.
+	version, err := utils.GenerateSecureToken(12)
+	if err != nil {
+		return nil, err
+	}
+	avatarKey := fmt.Sprintf("avatars/%s/%s", user.ID, version)
+
+	for _, size := range entity.AvatarSizes {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, utils.SquareThumbnail(img, size)); err != nil {
+			return nil, err
+		}
+
+		if err := s.fileStorage.Put(ctx, entity.AvatarObjectKey(avatarKey, size), &buf, int64(buf.Len()), "image/png"); err != nil {
+			deleteThumbnails(ctx, s.fileStorage, avatarKey)
+			return nil, err
+		}
+	}
+
+	previousKey := user.AvatarKey
+	user.AvatarKey = avatarKey
+	updatedUser, err := s.userRepo.Update(ctx, user)
+	if err != nil {
+		deleteThumbnails(ctx, s.fileStorage, avatarKey)
+		return nil, err
+	}
+
+	if previousKey != "" {
+		deleteThumbnails(ctx, s.fileStorage, previousKey)
+	}
+
+	return formatUserInfo(s.fileStorage, updatedUser), nil
+}
+
+func (s *AvatarService) DeleteAvatar(ctx context.Context, userID uuid.UUID) (*services.UserInfo, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if user.AvatarKey == "" {
+		return nil, errors.ErrAvatarNotFound
+	}
+
+	previousKey := user.AvatarKey
+	user.AvatarKey = ""
+	updatedUser, err := s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteThumbnails(ctx, s.fileStorage, previousKey)
+
+	return formatUserInfo(s.fileStorage, updatedUser), nil
+}
+
+// decode checks the size, sniffed type and pixel dimensions before decoding
+// so oversized or disguised uploads are rejected cheaply.
+func (s *AvatarService) decode(file io.Reader) (image.Image, error) {
+	data, err := io.ReadAll(io.LimitReader(file, s.avatarConfig.MaxFileSize+1))
+	if err != nil {
+		return nil, errors.ErrInvalidAvatarImage
+	}
+	if int64(len(data)) > s.avatarConfig.MaxFileSize {
+		return nil, errors.ErrAvatarTooLarge
+	}
+
+	if !avatarContentTypes[http.DetectContentType(data)] {
+		return nil, errors.ErrUnsupportedAvatarType
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.ErrInvalidAvatarImage
+	}
+	if imageConfig.Width > s.avatarConfig.MaxDimension || imageConfig.Height > s.avatarConfig.MaxDimension {
+		return nil, errors.ErrInvalidAvatarImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.ErrInvalidAvatarImage
+	}
+	return img, nil
+}
+
+// deleteThumbnails is best effort; orphaned objects are harmless.
+func deleteThumbnails(ctx context.Context, fileStorage ports.FileStorage, avatarKey string) {
+	for _, size := range entity.AvatarSizes {
+		key := entity.AvatarObjectKey(avatarKey, size)
+		if err := fileStorage.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete avatar %s: %v", key, err)
+		}
+	}
+}
@@ -1,52 +1,117 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
+	"go-gin-hexagonal/internal/domain/entity"
 	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
 	"go-gin-hexagonal/internal/domain/ports/services"
+
+	"github.com/google/uuid"
 )
 
+// emailSubjects holds the subject of every template by locale. Each subject
+// takes the application name.
+var emailSubjects = map[string]map[string]string{
+	"en": {
+		"invitation":               "You have been invited to %s",
+		"verify_email":             "Verify your email for %s",
+		"reset_password":           "Reset %s Account Password",
+		"password_expiry_reminder": "Your %s password is about to expire",
+		"new_sign_in":              "New sign-in to your %s account",
+		"registration_approved":    "Your %s account has been approved",
+		"registration_rejected":    "Your %s registration was declined",
+		"account_suspended":        "Your %s account has been suspended",
+		"account_reinstated":       "Your %s account has been reinstated",
+	},
+	"id": {
+		"invitation":               "Anda diundang ke %s",
+		"verify_email":             "Verifikasi email Anda untuk %s",
+		"reset_password":           "Atur Ulang Kata Sandi Akun %s",
+		"password_expiry_reminder": "Kata sandi %s Anda akan segera kedaluwarsa",
+		"new_sign_in":              "Aktivitas masuk baru ke akun %s Anda",
+		"registration_approved":    "Akun %s Anda telah disetujui",
+		"registration_rejected":    "Pendaftaran %s Anda ditolak",
+		"account_suspended":        "Akun %s Anda telah ditangguhkan",
+		"account_reinstated":       "Akun %s Anda telah dipulihkan",
+	},
+}
+
 type EmailService struct {
-	application string
-	mailer      ports.MailerManager
+	application     string
+	mailer          ports.MailerManager
+	preferencesRepo repositories.UserPreferencesRepository
 }
 
-func NewEmailService(smtp ports.MailerManager) services.EmailService {
+func NewEmailService(smtp ports.MailerManager, preferencesRepo repositories.UserPreferencesRepository) services.EmailService {
 	return &EmailService{
-		application: "Go Gin Hexagonal Application",
-		mailer:      smtp,
+		application:     "Go Gin Hexagonal Application",
+		mailer:          smtp,
+		preferencesRepo: preferencesRepo,
 	}
 }
 
-func (s *EmailService) SendNewUserEmail(to string, data *services.NewUserEmailData) error {
-	subject := fmt.Sprintf("Here is your new account information for %s", s.application)
+func (s *EmailService) SendInvitationEmail(to string, data *services.InvitationEmailData) error {
+	return s.send(to, "invitation", "", data)
+}
 
-	body, err := s.mailer.LoadEmailTemplate("new_user", data)
-	if err != nil {
-		return fmt.Errorf("failed to load welcome email template: %v", err)
-	}
+func (s *EmailService) SendVerifyEmail(to string, data *services.VerifyEmailData) error {
+	return s.send(to, "verify_email", "", data)
+}
 
-	return s.mailer.SendEmail(to, subject, body)
+func (s *EmailService) SendRequestResetPassword(to string, data *services.ResetPasswordData) error {
+	return s.send(to, "reset_password", "", data)
 }
 
-func (s *EmailService) SendVerifyEmail(to string, data *services.VerifyEmailData) error {
-	subject := fmt.Sprintf("Verify your email for %s", s.application)
+func (s *EmailService) SendPasswordExpiryReminder(to string, data *services.PasswordExpiryReminderData) error {
+	return s.send(to, "password_expiry_reminder", "", data)
+}
+
+func (s *EmailService) SendNewSignInEmail(to string, data *services.NewSignInEmailData) error {
+	return s.send(to, "new_sign_in", "", data)
+}
 
-	body, err := s.mailer.LoadEmailTemplate("verify_email", data)
+func (s *EmailService) SendRegistrationApprovedEmail(to string, data *services.RegistrationApprovedData) error {
+	return s.send(to, "registration_approved", entity.NotificationAccount, data)
+}
+
+func (s *EmailService) SendRegistrationRejectedEmail(to string, data *services.RegistrationRejectedData) error {
+	return s.send(to, "registration_rejected", entity.NotificationAccount, data)
+}
+
+func (s *EmailService) SendAccountSuspendedEmail(to string, data *services.AccountSuspendedData) error {
+	return s.send(to, "account_suspended", entity.NotificationAccount, data)
+}
+
+func (s *EmailService) SendAccountReinstatedEmail(to string, data *services.AccountReinstatedData) error {
+	return s.send(to, "account_reinstated", entity.NotificationAccount, data)
+}
+
+// send renders the template in the recipient's locale. Emails with a
+// category are skipped when the recipient opted out of it; security emails
+// have no category and are always sent.
+func (s *EmailService) send(to, templateName, category string, data any) error {
+	preferences, err := s.preferencesRepo.FindByEmail(context.Background(), to)
 	if err != nil {
-		return fmt.Errorf("failed to load verify email template: %v", err)
+		// Recipients without an account or saved preferences get the defaults.
+		preferences = entity.DefaultUserPreferences(uuid.Nil)
 	}
 
-	return s.mailer.SendEmail(to, subject, body)
-}
+	if category != "" && !preferences.WantsEmail(category) {
+		return nil
+	}
 
-func (s *EmailService) SendRequestResetPassword(to string, data *services.ResetPasswordData) error {
-	subject := fmt.Sprintf("Reset %s Account Password", s.application)
+	subjects, ok := emailSubjects[preferences.Locale]
+	if !ok {
+		subjects = emailSubjects[entity.DefaultLocale]
+	}
+	subject := fmt.Sprintf(subjects[templateName], s.application)
 
-	body, err := s.mailer.LoadEmailTemplate("reset_password", data)
+	body, err := s.mailer.LoadEmailTemplate(templateName, preferences.Locale, data)
 	if err != nil {
-		return fmt.Errorf("failed to load password reset request email template: %v", err)
+		return fmt.Errorf("failed to load %s email template: %v", templateName, err)
 	}
 
 	return s.mailer.SendEmail(to, subject, body)
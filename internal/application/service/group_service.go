@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"slices"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// groupNamePattern keeps names usable as identifiers in authorization
+// checks.
+var groupNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,99}$`)
+
+type GroupService struct {
+	groupRepo   repositories.GroupRepository
+	userRepo    repositories.UserRepository
+	fileStorage ports.FileStorage
+}
+
+func NewGroupService(groupRepo repositories.GroupRepository, userRepo repositories.UserRepository, fileStorage ports.FileStorage) services.GroupService {
+	return &GroupService{
+		groupRepo:   groupRepo,
+		userRepo:    userRepo,
+		fileStorage: fileStorage,
+	}
+}
+
+func FormatGroupInfo(group *entity.Group) *services.GroupInfo {
+	return &services.GroupInfo{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		ParentID:    group.ParentID,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
+
+func formatGroupInfos(groups []*entity.Group) []*services.GroupInfo {
+	infos := make([]*services.GroupInfo, 0, len(groups))
+	for _, group := range groups {
+		infos = append(infos, FormatGroupInfo(group))
+	}
+	return infos
+}
+
+func (s *GroupService) ListGroups(ctx context.Context) ([]*services.GroupInfo, error) {
+	groups, err := s.groupRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return formatGroupInfos(groups), nil
+}
+
+func (s *GroupService) GetGroup(ctx context.Context, id uuid.UUID) (*services.GroupInfo, error) {
+	group, err := s.groupRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.ErrGroupNotFound
+	}
+	return FormatGroupInfo(group), nil
+}
+
+func (s *GroupService) CreateGroup(ctx context.Context, req *services.CreateGroupRequest) (*services.GroupInfo, error) {
+	if !groupNamePattern.MatchString(req.Name) {
+		return nil, errors.ErrInvalidGroupName
+	}
+	if existing, _ := s.groupRepo.FindByName(ctx, req.Name); existing != nil {
+		return nil, errors.ErrGroupExists
+	}
+	if req.ParentID != nil {
+		if _, err := s.groupRepo.FindByID(ctx, *req.ParentID); err != nil {
+			return nil, errors.ErrGroupNotFound
+		}
+	}
+
+	group, err := s.groupRepo.Create(ctx, &entity.Group{
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return FormatGroupInfo(group), nil
+}
+
+func (s *GroupService) UpdateGroup(ctx context.Context, id uuid.UUID, req *services.UpdateGroupRequest) (*services.GroupInfo, error) {
+	group, err := s.groupRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.ErrGroupNotFound
+	}
+
+	if req.Name != nil && *req.Name != group.Name {
+		if !groupNamePattern.MatchString(*req.Name) {
+			return nil, errors.ErrInvalidGroupName
+		}
+		if existing, _ := s.groupRepo.FindByName(ctx, *req.Name); existing != nil {
+			return nil, errors.ErrGroupExists
+		}
+		group.Name = *req.Name
+	}
+	if req.Description != nil {
+		group.Description = *req.Description
+	}
+	if req.ParentID != nil {
+		if *req.ParentID == uuid.Nil {
+			group.ParentID = nil
+		} else {
+			if err := s.validateParent(ctx, id, *req.ParentID); err != nil {
+				return nil, err
+			}
+			parentID := *req.ParentID
+			group.ParentID = &parentID
+		}
+	}
+
+	updated, err := s.groupRepo.Update(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	return FormatGroupInfo(updated), nil
+}
+
+// validateParent rejects moves that would turn the hierarchy into a cycle.
+func (s *GroupService) validateParent(ctx context.Context, groupID, parentID uuid.UUID) error {
+	if parentID == groupID {
+		return errors.ErrInvalidGroupParent
+	}
+	if _, err := s.groupRepo.FindByID(ctx, parentID); err != nil {
+		return errors.ErrGroupNotFound
+	}
+
+	descendants, err := s.groupRepo.FindDescendantIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(descendants, parentID) {
+		return errors.ErrInvalidGroupParent
+	}
+	return nil
+}
+
+func (s *GroupService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.groupRepo.FindByID(ctx, id); err != nil {
+		return errors.ErrGroupNotFound
+	}
+
+	descendants, err := s.groupRepo.FindDescendantIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(descendants) > 0 {
+		return errors.ErrGroupHasSubgroups
+	}
+
+	return s.groupRepo.Delete(ctx, id)
+}
+
+func (s *GroupService) ListMembers(ctx context.Context, groupID uuid.UUID, req *services.ListGroupMembersRequest) (*services.UserPaginationResponse, error) {
+	if _, err := s.groupRepo.FindByID(ctx, groupID); err != nil {
+		return nil, errors.ErrGroupNotFound
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	users, total, err := s.groupRepo.FindMembers(ctx, groupID, req.Nested, req.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatUserPage(s.fileStorage, users, total, req.Page, req.PageSize), nil
+}
+
+func (s *GroupService) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	if _, err := s.groupRepo.FindByID(ctx, groupID); err != nil {
+		return errors.ErrGroupNotFound
+	}
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return errors.ErrUserNotFound
+	}
+	if member, _ := s.groupRepo.FindMember(ctx, groupID, userID); member != nil {
+		return errors.ErrGroupMemberExists
+	}
+
+	return s.groupRepo.AddMember(ctx, &entity.GroupMember{
+		GroupID: groupID,
+		UserID:  userID,
+	})
+}
+
+func (s *GroupService) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	if _, err := s.groupRepo.FindByID(ctx, groupID); err != nil {
+		return errors.ErrGroupNotFound
+	}
+	if member, _ := s.groupRepo.FindMember(ctx, groupID, userID); member == nil {
+		return errors.ErrGroupMemberNotFound
+	}
+
+	return s.groupRepo.RemoveMember(ctx, groupID, userID)
+}
+
+func (s *GroupService) ListUserGroups(ctx context.Context, userID uuid.UUID) ([]*services.GroupInfo, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	groups, err := s.groupRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return formatGroupInfos(groups), nil
+}
+
+func (s *GroupService) IsMember(ctx context.Context, userID uuid.UUID, names ...string) (bool, error) {
+	groups, err := s.groupRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, group := range groups {
+		if slices.Contains(names, group.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// importFlushSize is how many rows are processed between progress writes.
+const importFlushSize = 50
+
+// importAttributePrefix marks CSV columns holding custom attribute values,
+// e.g. "attributes.department".
+const importAttributePrefix = "attributes."
+
+type ImportService struct {
+	importRepo    repositories.ImportJobRepository
+	attributeRepo repositories.AttributeDefinitionRepository
+	userService   services.UserService
+	importConfig  config.ImportConfig
+}
+
+func NewImportService(importRepo repositories.ImportJobRepository, attributeRepo repositories.AttributeDefinitionRepository, userService services.UserService, importConfig config.ImportConfig) services.ImportService {
+	return &ImportService{
+		importRepo:    importRepo,
+		attributeRepo: attributeRepo,
+		userService:   userService,
+		importConfig:  importConfig,
+	}
+}
+
+type importRecord struct {
+	Email      string         `json:"email"`
+	Name       string         `json:"name"`
+	Attributes map[string]any `json:"attributes"`
+	// attributeText holds CSV attribute values, which are typed once the
+	// attribute definitions are known.
+	attributeText map[string]string
+}
+
+func FormatImportJobInfo(job *entity.ImportJob) *services.ImportJobInfo {
+	return &services.ImportJobInfo{
+		ID:              job.ID,
+		CreatedBy:       job.CreatedBy,
+		Format:          job.Format,
+		DryRun:          job.DryRun,
+		SendInvitations: job.SendInvitations,
+		Status:          job.Status,
+		Total:           job.Total,
+		Processed:       job.Processed,
+		Succeeded:       job.Succeeded,
+		Failed:          job.Failed,
+		Error:           job.Error,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+		CreatedAt:       job.CreatedAt,
+	}
+}
+
+func formatImportRowInfo(row *entity.ImportJobRow) *services.ImportRowInfo {
+	return &services.ImportRowInfo{
+		Row:            row.Row,
+		Email:          row.Email,
+		Name:           row.Name,
+		Status:         row.Status,
+		UserID:         row.UserID,
+		InvitationSent: row.InvitationSent,
+		Error:          row.Error,
+	}
+}
+
+func (s *ImportService) StartImport(ctx context.Context, req *services.ImportRequest) (*services.ImportJobInfo, error) {
+	job, records, err := s.queue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	info := FormatImportJobInfo(job)
+
+	// The job outlives the request, so keep its values but not its deadline.
+	go s.process(context.WithoutCancel(ctx), job, records)
+
+	return info, nil
+}
+
+func (s *ImportService) RunImport(ctx context.Context, req *services.ImportRequest) (*services.ImportJobInfo, error) {
+	job, records, err := s.queue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.process(ctx, job, records)
+
+	return FormatImportJobInfo(job), nil
+}
+
+func (s *ImportService) GetImport(ctx context.Context, jobID uuid.UUID, page, pageSize int) (*services.ImportJobDetail, error) {
+	job, err := s.importRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, errors.ErrImportJobNotFound
+	}
+
+	offset := (page - 1) * pageSize
+	rows, total, err := s.importRepo.FindRows(ctx, jobID, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	rowInfos := make([]*services.ImportRowInfo, 0, len(rows))
+	for _, row := range rows {
+		rowInfos = append(rowInfos, formatImportRowInfo(row))
+	}
+
+	return &services.ImportJobDetail{
+		Job:        FormatImportJobInfo(job),
+		Rows:       rowInfos,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+	}, nil
+}
+
+// queue parses the whole file before recording the job so that malformed
+// uploads are rejected up front instead of failing in the background.
+func (s *ImportService) queue(ctx context.Context, req *services.ImportRequest) (*entity.ImportJob, []importRecord, error) {
+	records, err := s.parse(req.Format, req.File)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job, err := s.importRepo.Create(ctx, &entity.ImportJob{
+		CreatedBy:       req.CreatedBy,
+		Format:          req.Format,
+		DryRun:          req.DryRun,
+		SendInvitations: req.SendInvitations && !req.DryRun,
+		Status:          entity.ImportStatusQueued,
+		Total:           len(records),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, records, nil
+}
+
+func (s *ImportService) parse(format string, file io.Reader) ([]importRecord, error) {
+	var data []byte
+	var err error
+	if s.importConfig.MaxFileSize > 0 {
+		data, err = io.ReadAll(io.LimitReader(file, s.importConfig.MaxFileSize+1))
+		if err == nil && int64(len(data)) > s.importConfig.MaxFileSize {
+			return nil, errors.ErrImportTooLarge
+		}
+	} else {
+		data, err = io.ReadAll(file)
+	}
+	if err != nil {
+		return nil, errors.ErrInvalidImportFile
+	}
+
+	var records []importRecord
+	switch format {
+	case entity.ImportFormatCSV:
+		records, err = parseImportCSV(data)
+	case entity.ImportFormatJSON:
+		records, err = parseImportJSON(data)
+	default:
+		return nil, errors.ErrInvalidImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.ErrImportEmpty
+	}
+	if s.importConfig.MaxRows > 0 && len(records) > s.importConfig.MaxRows {
+		return nil, errors.ErrImportTooManyRows
+	}
+
+	return records, nil
+}
+
+// parseImportCSV expects a header row naming the email and name columns, in
+// any order and case. Columns named "attributes.<key>" set custom attributes
+// and are skipped when empty; other columns are ignored.
+func parseImportCSV(data []byte) ([]importRecord, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.ErrImportEmpty
+	}
+	if err != nil {
+		return nil, errors.ErrInvalidImportFile
+	}
+
+	emailColumn, nameColumn := -1, -1
+	attributeColumns := make(map[int]string)
+	for i, column := range header {
+		switch name := strings.ToLower(strings.TrimSpace(column)); {
+		case name == "email":
+			emailColumn = i
+		case name == "name":
+			nameColumn = i
+		case strings.HasPrefix(name, importAttributePrefix):
+			attributeColumns[i] = strings.TrimPrefix(name, importAttributePrefix)
+		}
+	}
+	if emailColumn < 0 || nameColumn < 0 {
+		return nil, errors.ErrInvalidImportFile
+	}
+
+	var records []importRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.ErrInvalidImportFile
+		}
+
+		var record importRecord
+		if emailColumn < len(fields) {
+			record.Email = fields[emailColumn]
+		}
+		if nameColumn < len(fields) {
+			record.Name = fields[nameColumn]
+		}
+		for i, key := range attributeColumns {
+			if i < len(fields) && strings.TrimSpace(fields[i]) != "" {
+				if record.attributeText == nil {
+					record.attributeText = make(map[string]string)
+				}
+				record.attributeText[key] = strings.TrimSpace(fields[i])
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseImportJSON(data []byte) ([]importRecord, error) {
+	var records []importRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.ErrInvalidImportFile
+	}
+	return records, nil
+}
+
+func (s *ImportService) process(ctx context.Context, job *entity.ImportJob, records []importRecord) {
+	now := time.Now()
+	job.Status = entity.ImportStatusRunning
+	job.StartedAt = &now
+	if _, err := s.importRepo.Update(ctx, job); err != nil {
+		s.fail(ctx, job, err)
+		return
+	}
+
+	definitions, err := s.attributeRepo.FindAll(ctx)
+	if err != nil {
+		s.fail(ctx, job, err)
+		return
+	}
+	attributeDefinitions := make(map[string]*entity.AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		attributeDefinitions[definition.Key] = definition
+	}
+
+	seen := make(map[string]bool, len(records))
+	batch := make([]*entity.ImportJobRow, 0, importFlushSize)
+
+	for i, record := range records {
+		row := s.processRow(ctx, job, i+1, record, attributeDefinitions, seen)
+
+		job.Processed++
+		if row.Status == entity.ImportRowFailed {
+			job.Failed++
+		} else {
+			job.Succeeded++
+		}
+
+		batch = append(batch, row)
+		if len(batch) == importFlushSize {
+			if err := s.flush(ctx, job, batch); err != nil {
+				s.fail(ctx, job, err)
+				return
+			}
+			batch = make([]*entity.ImportJobRow, 0, importFlushSize)
+		}
+	}
+
+	if err := s.importRepo.CreateRows(ctx, batch); err != nil {
+		s.fail(ctx, job, err)
+		return
+	}
+
+	finishedAt := time.Now()
+	job.Status = entity.ImportStatusCompleted
+	job.FinishedAt = &finishedAt
+	if _, err := s.importRepo.Update(ctx, job); err != nil {
+		log.Printf("failed to complete import job %s: %v", job.ID, err)
+	}
+}
+
+func (s *ImportService) processRow(ctx context.Context, job *entity.ImportJob, position int, record importRecord, attributeDefinitions map[string]*entity.AttributeDefinition, seen map[string]bool) *entity.ImportJobRow {
+	row := &entity.ImportJobRow{
+		ImportJobID: job.ID,
+		Row:         position,
+		Email:       strings.TrimSpace(record.Email),
+		Name:        strings.TrimSpace(record.Name),
+		CreatedAt:   time.Now(),
+	}
+
+	key := strings.ToLower(row.Email)
+	if key != "" && seen[key] {
+		return failImportRow(row, errors.ErrDuplicateImportRow)
+	}
+	seen[key] = true
+
+	attributes, err := importAttributes(record, attributeDefinitions)
+	if err != nil {
+		return failImportRow(row, err)
+	}
+
+	req := &services.CreateUserRequest{
+		Email:          row.Email,
+		Name:           row.Name,
+		Attributes:     attributes,
+		SkipInvitation: true,
+	}
+
+	if err := s.userService.ValidateNewUser(ctx, req); err != nil {
+		return failImportRow(row, err)
+	}
+
+	if job.DryRun {
+		row.Status = entity.ImportRowValid
+		return row
+	}
+
+	user, err := s.userService.CreateUser(ctx, req)
+	if err != nil {
+		return failImportRow(row, err)
+	}
+
+	row.Status = entity.ImportRowCreated
+	row.UserID = &user.ID
+
+	// A failed invitation leaves the user in place; it can be resent later.
+	if job.SendInvitations {
+		if err := s.userService.InviteUser(ctx, user.ID); err != nil {
+			row.Error = "invitation not sent: " + err.Error()
+		} else {
+			row.InvitationSent = true
+		}
+	}
+
+	return row
+}
+
+// importAttributes returns the custom attributes of a record. CSV values are
+// converted to their attribute's type; unknown keys are passed on as text for
+// CreateUser to reject.
+func importAttributes(record importRecord, definitions map[string]*entity.AttributeDefinition) (map[string]any, error) {
+	if record.attributeText == nil {
+		return record.Attributes, nil
+	}
+
+	attributes := make(map[string]any, len(record.attributeText))
+	for key, text := range record.attributeText {
+		definition, ok := definitions[key]
+		if !ok {
+			attributes[key] = text
+			continue
+		}
+
+		value, err := parseAttributeText(definition, text)
+		if err != nil {
+			return nil, &errors.AttributeError{Key: key, Err: err}
+		}
+		attributes[key] = value
+	}
+
+	return attributes, nil
+}
+
+func failImportRow(row *entity.ImportJobRow, err error) *entity.ImportJobRow {
+	row.Status = entity.ImportRowFailed
+	row.Error = err.Error()
+	return row
+}
+
+func (s *ImportService) flush(ctx context.Context, job *entity.ImportJob, rows []*entity.ImportJobRow) error {
+	if err := s.importRepo.CreateRows(ctx, rows); err != nil {
+		return err
+	}
+	_, err := s.importRepo.Update(ctx, job)
+	return err
+}
+
+func (s *ImportService) fail(ctx context.Context, job *entity.ImportJob, cause error) {
+	log.Printf("import job %s failed: %v", job.ID, cause)
+
+	finishedAt := time.Now()
+	job.Status = entity.ImportStatusFailed
+	job.Error = cause.Error()
+	job.FinishedAt = &finishedAt
+	if _, err := s.importRepo.Update(ctx, job); err != nil {
+		log.Printf("failed to record import job %s failure: %v", job.ID, err)
+	}
+}
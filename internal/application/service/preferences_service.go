@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+	_ "time/tzdata" // timezones validate even where the host has no zoneinfo
+
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type PreferencesService struct {
+	preferencesRepo repositories.UserPreferencesRepository
+	userRepo        repositories.UserRepository
+}
+
+func NewPreferencesService(preferencesRepo repositories.UserPreferencesRepository, userRepo repositories.UserRepository) services.PreferencesService {
+	return &PreferencesService{
+		preferencesRepo: preferencesRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// FormatPreferencesInfo lists every notification category, so opted-in
+// categories show up even when they were never saved.
+func FormatPreferencesInfo(preferences *entity.UserPreferences) *services.PreferencesInfo {
+	notifications := make(map[string]bool, len(entity.NotificationCategories))
+	for _, category := range entity.NotificationCategories {
+		notifications[category] = preferences.WantsEmail(category)
+	}
+
+	return &services.PreferencesInfo{
+		Locale:             preferences.Locale,
+		Timezone:           preferences.Timezone,
+		DateFormat:         preferences.DateFormat,
+		EmailNotifications: notifications,
+		UpdatedAt:          preferences.UpdatedAt,
+	}
+}
+
+func (s *PreferencesService) GetPreferences(ctx context.Context, userID uuid.UUID) (*services.PreferencesInfo, error) {
+	preferences, err := s.findPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return FormatPreferencesInfo(preferences), nil
+}
+
+func (s *PreferencesService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req *services.UpdatePreferencesRequest) (*services.PreferencesInfo, error) {
+	if err := validatePreferences(req); err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.findPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Locale != nil {
+		preferences.Locale = *req.Locale
+	}
+	if req.Timezone != nil {
+		preferences.Timezone = *req.Timezone
+	}
+	if req.DateFormat != nil {
+		preferences.DateFormat = *req.DateFormat
+	}
+	if preferences.EmailNotifications == nil {
+		preferences.EmailNotifications = entity.NotificationSettings{}
+	}
+	maps.Copy(preferences.EmailNotifications, req.EmailNotifications)
+
+	saved, err := s.preferencesRepo.Save(ctx, preferences)
+	if err != nil {
+		return nil, err
+	}
+	return FormatPreferencesInfo(saved), nil
+}
+
+func (s *PreferencesService) findPreferences(ctx context.Context, userID uuid.UUID) (*entity.UserPreferences, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	preferences, err := s.preferencesRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return entity.DefaultUserPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+func validatePreferences(req *services.UpdatePreferencesRequest) error {
+	if req.Locale != nil && !slices.Contains(entity.Locales, *req.Locale) {
+		return errors.ErrUnsupportedLocale
+	}
+	if req.Timezone != nil {
+		// LoadLocation accepts "" and "Local", neither of which names a zone.
+		if *req.Timezone == "" || *req.Timezone == "Local" {
+			return errors.ErrInvalidTimezone
+		}
+		if _, err := time.LoadLocation(*req.Timezone); err != nil {
+			return errors.ErrInvalidTimezone
+		}
+	}
+	if req.DateFormat != nil && !slices.Contains(entity.DateFormats, *req.DateFormat) {
+		return errors.ErrInvalidDateFormat
+	}
+	for category := range req.EmailNotifications {
+		if !slices.Contains(entity.NotificationCategories, category) {
+			return errors.ErrUnknownNotificationCategory
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	_ "embed"
+	"strings"
+
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainList string
+
+type RegistrationPolicy struct {
+	allowedDomains    map[string]struct{}
+	blockedDomains    map[string]struct{}
+	disposableDomains map[string]struct{}
+	requireApproval   bool
+}
+
+func NewRegistrationPolicy(cfg config.RegistrationPolicyConfig) services.RegistrationPolicy {
+	policy := &RegistrationPolicy{
+		allowedDomains:  domainSet(cfg.AllowedDomains),
+		blockedDomains:  domainSet(cfg.BlockedDomains),
+		requireApproval: cfg.RequireApproval,
+	}
+
+	if cfg.BlockDisposable {
+		var domains []string
+		for _, line := range strings.Split(disposableDomainList, "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				domains = append(domains, line)
+			}
+		}
+		policy.disposableDomains = domainSet(domains)
+	}
+
+	return policy
+}
+
+// CheckEmail applies the deny list first, then the allow list. Domains that
+// are explicitly allowed skip the disposable mailbox check.
+func (p *RegistrationPolicy) CheckEmail(email string) error {
+	domain := emailDomain(email)
+	if domain == "" {
+		return errors.ErrInvalidInput
+	}
+
+	if matchesDomain(domain, p.blockedDomains) {
+		return errors.ErrEmailDomainBlocked
+	}
+
+	if len(p.allowedDomains) > 0 {
+		if !matchesDomain(domain, p.allowedDomains) {
+			return errors.ErrEmailDomainNotAllowed
+		}
+		return nil
+	}
+
+	if matchesDomain(domain, p.disposableDomains) {
+		return errors.ErrDisposableEmail
+	}
+
+	return nil
+}
+
+func (p *RegistrationPolicy) RequiresApproval() bool {
+	return p.requireApproval
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(email[at+1:])), ".")
+}
+
+func domainSet(domains []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		set[strings.TrimPrefix(strings.ToLower(domain), "@")] = struct{}{}
+	}
+	return set
+}
+
+// matchesDomain reports whether domain or one of its parent domains is in set.
+func matchesDomain(domain string, set map[string]struct{}) bool {
+	for {
+		if _, ok := set[domain]; ok {
+			return true
+		}
+
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
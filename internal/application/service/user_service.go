@@ -2,13 +2,23 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"maps"
 	"math"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"go-gin-hexagonal/internal/domain/entity"
 	"go-gin-hexagonal/internal/domain/ports"
 	"go-gin-hexagonal/internal/domain/ports/repositories"
 	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
 	"go-gin-hexagonal/pkg/errors"
 	"go-gin-hexagonal/pkg/utils"
 
@@ -16,56 +26,165 @@ import (
 )
 
 type UserService struct {
-	userRepo       repositories.UserRepository
-	passwordHasher ports.PasswordHasher
-	emailService   services.EmailService
+	userRepo         repositories.UserRepository
+	passwordHasher   ports.PasswordHasher
+	emailService     services.EmailService
+	invitationRepo   repositories.InvitationRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	invitationConfig config.InvitationConfig
+	passwordPolicy   config.PasswordPolicyConfig
+	registration     services.RegistrationPolicy
+	fileStorage      ports.FileStorage
+	attributeRepo    repositories.AttributeDefinitionRepository
+	deletionConfig   config.DeletionConfig
+	encryptor        ports.Encryptor
+	auditService     services.AuditService
+	usernamePolicy   services.UsernamePolicy
 }
 
 func NewUserService(
 	userRepo repositories.UserRepository,
 	passwordHasher ports.PasswordHasher,
 	emailService services.EmailService,
+	invitationRepo repositories.InvitationRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	invitationConfig config.InvitationConfig,
+	passwordPolicy config.PasswordPolicyConfig,
+	registration services.RegistrationPolicy,
+	fileStorage ports.FileStorage,
+	attributeRepo repositories.AttributeDefinitionRepository,
+	deletionConfig config.DeletionConfig,
+	encryptor ports.Encryptor,
+	auditService services.AuditService,
+	usernamePolicy services.UsernamePolicy,
 ) services.UserService {
 	return &UserService{
-		userRepo:       userRepo,
-		passwordHasher: passwordHasher,
-		emailService:   emailService,
+		userRepo:         userRepo,
+		passwordHasher:   passwordHasher,
+		emailService:     emailService,
+		invitationRepo:   invitationRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		invitationConfig: invitationConfig,
+		passwordPolicy:   passwordPolicy,
+		registration:     registration,
+		fileStorage:      fileStorage,
+		attributeRepo:    attributeRepo,
+		deletionConfig:   deletionConfig,
+		encryptor:        encryptor,
+		auditService:     auditService,
+		usernamePolicy:   usernamePolicy,
 	}
 }
 
+func getInvitationURL(token string) string {
+	return fmt.Sprintf("%s/accept-invitation?token=%s", config.GetAppURL(), url.QueryEscape(token))
+}
+
+func getLoginURL() string {
+	return fmt.Sprintf("%s/login", config.GetAppURL())
+}
+
+func getChangePasswordURL() string {
+	return fmt.Sprintf("%s/change-password", config.GetAppURL())
+}
+
 func FormatUserInfo(user *entity.User) *services.UserInfo {
 	return &services.UserInfo{
-		ID:        user.ID,
-		Email:     user.Email,
-		Username:  user.Username,
-		Name:      user.Name,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              user.ID,
+		Email:           user.Email,
+		Username:        user.Username,
+		Name:            user.Name,
+		Role:            user.Role,
+		Status:          user.Status,
+		StatusReason:    user.StatusReason,
+		StatusChangedAt: user.StatusChangedAt,
+		SuspendedUntil:  user.SuspendedUntil,
+		ApprovalStatus:  user.ApprovalStatus,
+		Attributes:      user.Attributes,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		DeletedAt:       user.DeletedAt,
+		Version:         user.Version,
 	}
 }
 
-func (s *UserService) GetAllUsers(ctx context.Context, page, pageSize int, search string) (*services.UserPaginationResponse, error) {
-	offset := (page - 1) * pageSize
-	users, total, err := s.userRepo.FindAll(ctx, pageSize, offset, search)
+// formatUserInfo extends FormatUserInfo with avatar URLs, which depend on
+// where the thumbnails are stored.
+func formatUserInfo(fileStorage ports.FileStorage, user *entity.User) *services.UserInfo {
+	info := FormatUserInfo(user)
+	if user.AvatarKey == "" || fileStorage == nil {
+		return info
+	}
+
+	info.AvatarURLs = make(map[string]string, len(entity.AvatarSizes))
+	for _, size := range entity.AvatarSizes {
+		info.AvatarURLs[strconv.Itoa(size)] = fileStorage.URL(entity.AvatarObjectKey(user.AvatarKey, size))
+	}
+	return info
+}
+
+// userListQuery maps a listing request to repository filter and sort options.
+func userListQuery(ctx context.Context, attributeRepo repositories.AttributeDefinitionRepository, req *services.ListUsersRequest) (*repositories.UserFilter, *repositories.SortOption, error) {
+	attributes, err := attributeFilter(ctx, attributeRepo, req.Attributes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := &repositories.UserFilter{
+		IncludeDeleted: req.IncludeDeleted,
+		Search:         strings.TrimSpace(req.Search),
+		Email:          req.Email,
+		Status:         req.Status,
+		IsActive:       req.IsActive,
+		CreatedFrom:    req.CreatedFrom,
+		CreatedTo:      req.CreatedTo,
+		UpdatedFrom:    req.UpdatedFrom,
+		UpdatedTo:      req.UpdatedTo,
+		Attributes:     attributes,
+	}
+	return filter, &repositories.SortOption{Field: req.Sort, Desc: req.Order == "desc"}, nil
+}
+
+func (s *UserService) GetAllUsers(ctx context.Context, req *services.ListUsersRequest) (*services.UserPaginationResponse, error) {
+	filter, sort, err := userListQuery(ctx, s.attributeRepo, req)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.Cursor != nil {
+		page, err := s.userRepo.FindPage(ctx, filter, sort, *req.Cursor, req.PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		response := formatUserPage(s.fileStorage, page.Items, 0, 0, req.PageSize)
+		response.NextCursor = page.NextCursor
+		response.PrevCursor = page.PrevCursor
+		return response, nil
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	users, total, err := s.userRepo.FindAll(ctx, filter, sort, req.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatUserPage(s.fileStorage, users, total, req.Page, req.PageSize), nil
+}
+
+func formatUserPage(fileStorage ports.FileStorage, users []*entity.User, total int64, page, pageSize int) *services.UserPaginationResponse {
 	var userInfos []*services.UserInfo
 	for _, user := range users {
-		userInfos = append(userInfos, FormatUserInfo(user))
+		userInfos = append(userInfos, formatUserInfo(fileStorage, user))
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
-
 	return &services.UserPaginationResponse{
 		Datas:      userInfos,
 		Total:      total,
 		Page:       page,
 		PageSize:   pageSize,
-		TotalPages: totalPages,
-	}, nil
+		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+	}
 }
 
 func (s *UserService) GetUserByID(ctx context.Context, userID uuid.UUID) (*services.UserInfo, error) {
@@ -74,42 +193,78 @@ func (s *UserService) GetUserByID(ctx context.Context, userID uuid.UUID) (*servi
 		return nil, errors.ErrUserNotFound
 	}
 
-	return FormatUserInfo(user), nil
+	return formatUserInfo(s.fileStorage, user), nil
 }
 
-func (s *UserService) CreateUser(ctx context.Context, req *services.CreateUserRequest) (*services.UserInfo, error) {
+func (s *UserService) GetUserByUsername(ctx context.Context, username string) (*services.UserInfo, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	return formatUserInfo(s.fileStorage, user), nil
+}
+
+// ValidateNewUser applies the rules CreateUser enforces without creating
+// anything, so bulk imports can check rows up front.
+func (s *UserService) ValidateNewUser(ctx context.Context, req *services.CreateUserRequest) error {
+	_, err := s.validateNewUser(ctx, req)
+	return err
+}
+
+// validateNewUser also returns the validated custom attributes.
+func (s *UserService) validateNewUser(ctx context.Context, req *services.CreateUserRequest) (entity.UserAttributes, error) {
 	if req.Email == "" || req.Name == "" {
 		return nil, errors.ErrInvalidInput
 	}
 
+	if address, err := mail.ParseAddress(req.Email); err != nil || address.Address != req.Email {
+		return nil, errors.ErrInvalidEmail
+	}
+
+	if length := utf8.RuneCountInString(req.Name); length < 3 || length > 100 {
+		return nil, errors.ErrInvalidName
+	}
+
+	if err := s.registration.CheckEmail(req.Email); err != nil {
+		return nil, err
+	}
+
 	if s.userRepo.ExistsByEmail(ctx, req.Email) {
 		return nil, errors.ErrUserAlreadyExists
 	}
 
+	return resolveAttributes(ctx, s.attributeRepo, s.userRepo, uuid.Nil, nil, req.Attributes, true)
+}
+
+func (s *UserService) CreateUser(ctx context.Context, req *services.CreateUserRequest) (*services.UserInfo, error) {
+	attributes, err := s.validateNewUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	var username string
 	var usernameExists = true
 	for usernameExists {
 		username = utils.GenerateUsername(req.Name)
 
-		existingUser := s.userRepo.ExistsByUsername(ctx, username)
+		existingUser := s.userRepo.ExistsByUsername(ctx, username) || s.usernamePolicy.IsReserved(username)
 		if !existingUser {
 			usernameExists = false
 			break
 		}
 	}
 
-	password := utils.GeneratePassword(8, true)
-	hashedPassword, err := s.passwordHasher.Hash(password)
-	if err != nil {
-		return nil, err
-	}
-
+	// The user has no password and stays pending until the invitation is
+	// accepted and a password is chosen.
 	user := &entity.User{
-		Email:    req.Email,
-		Username: username,
-		Password: hashedPassword,
-		Name:     req.Name,
-		IsActive: true,
+		Email:             req.Email,
+		Username:          username,
+		Name:              req.Name,
+		Role:              entity.RoleUser,
+		Status:            entity.UserStatusPendingVerification,
+		Attributes:        attributes,
+		FirstLoginPending: true,
 	}
 
 	createdUser, err := s.userRepo.Create(ctx, user)
@@ -117,39 +272,146 @@ func (s *UserService) CreateUser(ctx context.Context, req *services.CreateUserRe
 		return nil, err
 	}
 
-	go func(email string, username string, password string) {
-		newUserData := &services.NewUserEmailData{
-			Email:    email,
-			Password: password,
+	if !req.SkipInvitation {
+		if err := s.sendInvitation(ctx, createdUser); err != nil {
+			return nil, err
 		}
+	}
+
+	return formatUserInfo(s.fileStorage, createdUser), nil
+}
+
+// InviteUser creates an invitation and emails it before returning, so the
+// caller learns whether delivery failed.
+func (s *UserService) InviteUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.ErrUserNotFound
+	}
+
+	invitationData, err := s.createInvitation(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.SendInvitationEmail(user.Email, invitationData)
+}
 
-		err := s.emailService.SendNewUserEmail(email, newUserData)
+func (s *UserService) sendInvitation(ctx context.Context, user *entity.User) error {
+	invitationData, err := s.createInvitation(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	go func(email string) {
+		err := s.emailService.SendInvitationEmail(email, invitationData)
 		if err != nil {
-			log.Printf("failed to send new user email: %v", err)
+			log.Printf("failed to send invitation email: %v", err)
 		}
-	}(req.Email, username, password)
+	}(user.Email)
+
+	return nil
+}
+
+func (s *UserService) createInvitation(ctx context.Context, user *entity.User) (*services.InvitationEmailData, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &entity.Invitation{
+		UserID:    user.ID,
+		TokenHash: utils.HashSHA256(token),
+		ExpiresAt: time.Now().Add(s.invitationConfig.Expiry),
+	}
+
+	if _, err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return &services.InvitationEmailData{
+		Name:          user.Name,
+		InvitationURL: getInvitationURL(token),
+		ExpiresAt:     invitation.ExpiresAt.Format(utils.TimeFormat),
+	}, nil
+}
+
+func (s *UserService) ResendInvitation(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.ErrUserNotFound
+	}
 
-	return FormatUserInfo(createdUser), nil
+	if user.Status != entity.UserStatusPendingVerification {
+		return errors.ErrInvitationAlreadyAccepted
+	}
+
+	if err := s.invitationRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.sendInvitation(ctx, user)
+}
+
+func (s *UserService) RevokeInvitation(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.invitationRepo.FindPendingByUserID(ctx, userID); err != nil {
+		return errors.ErrInvitationNotFound
+	}
+
+	return s.invitationRepo.RevokeAllByUserID(ctx, userID)
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, userID uuid.UUID, req *services.UpdateUserRequest) (*services.UserInfo, error) {
+	return s.updateUser(ctx, userID, req, false)
+}
+
+// updateUser applies the update. Admins may hand out reserved usernames and
+// are not held to the username change cooldown.
+func (s *UserService) updateUser(ctx context.Context, userID uuid.UUID, req *services.UpdateUserRequest, byAdmin bool) (*services.UserInfo, error) {
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, errors.ErrUserNotFound
 	}
 
+	if req.Versions != nil && !slices.Contains(req.Versions, user.Version) {
+		return nil, errors.ErrVersionConflict
+	}
+
 	if req.Name != nil {
 		user.Name = *req.Name
 	}
 
-	if req.Username != nil {
+	now := time.Now()
+	var replacedUsername string
+	if req.Username != nil && *req.Username != user.Username {
+		if !byAdmin {
+			if s.usernamePolicy.IsReserved(*req.Username) {
+				return nil, errors.ErrUsernameReserved
+			}
+			if user.UsernameChangedAt != nil && now.Before(s.usernamePolicy.NextChangeAt(*user.UsernameChangedAt)) {
+				return nil, errors.ErrUsernameChangeCooldown
+			}
+		}
+
 		if s.userRepo.ExistsByUsername(ctx, *req.Username) {
+			// No match means a soft deleted user still holds the username;
+			// a match on the user itself means they are taking back an alias.
 			existingUser, _ := s.userRepo.FindByUsername(ctx, *req.Username)
-			if existingUser != nil && existingUser.ID != userID {
+			if existingUser == nil || existingUser.ID != userID {
 				return nil, errors.ErrUserAlreadyExists
 			}
 		}
+		replacedUsername = user.Username
 		user.Username = *req.Username
+		user.UsernameChangedAt = &now
+	}
+
+	if len(req.Attributes) > 0 {
+		attributes, err := resolveAttributes(ctx, s.attributeRepo, s.userRepo, userID, user.Attributes, req.Attributes, false)
+		if err != nil {
+			return nil, err
+		}
+		user.Attributes = attributes
 	}
 
 	updatedUser, err := s.userRepo.Update(ctx, user)
@@ -157,7 +419,31 @@ func (s *UserService) UpdateUser(ctx context.Context, userID uuid.UUID, req *ser
 		return nil, err
 	}
 
-	return FormatUserInfo(updatedUser), nil
+	if replacedUsername != "" {
+		if err := s.replaceUsernameAlias(ctx, userID, replacedUsername, updatedUser.Username, now); err != nil {
+			log.Printf("failed to update aliases after username change of user %s: %v", userID, err)
+		}
+	}
+
+	return formatUserInfo(s.fileStorage, updatedUser), nil
+}
+
+// replaceUsernameAlias keeps the old username pointing at the user for the
+// retention period and drops any alias for the username they just took.
+func (s *UserService) replaceUsernameAlias(ctx context.Context, userID uuid.UUID, oldUsername, newUsername string, now time.Time) error {
+	if err := s.userRepo.DeleteUsernameAlias(ctx, newUsername); err != nil {
+		return err
+	}
+
+	retention := s.usernamePolicy.AliasRetention()
+	if retention <= 0 {
+		return nil
+	}
+	return s.userRepo.SaveUsernameAlias(ctx, &entity.UsernameAlias{
+		Username:  oldUsername,
+		UserID:    userID,
+		ExpiresAt: now.Add(retention),
+	})
 }
 
 func (s *UserService) ChangePassword(ctx context.Context, userID uuid.UUID, req *services.ChangePasswordRequest) error {
@@ -171,20 +457,520 @@ func (s *UserService) ChangePassword(ctx context.Context, userID uuid.UUID, req
 		return errors.ErrInvalidCredentials
 	}
 
+	if req.NewPassword == req.CurrentPassword {
+		return errors.ErrPasswordReused
+	}
+
 	hashedPassword, err := s.passwordHasher.Hash(req.NewPassword)
 	if err != nil {
 		return err
 	}
 
-	user.Password = hashedPassword
+	user.SetPassword(hashedPassword, time.Now())
 	_, err = s.userRepo.Update(ctx, user)
 
 	return err
 }
 
-func (s *UserService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+// SendPasswordExpiryReminders emails every user whose password expires within
+// the configured reminder window. Each password is reminded about once.
+func (s *UserService) SendPasswordExpiryReminders(ctx context.Context) error {
+	if s.passwordPolicy.MaxAge <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	changedBefore := now.Add(s.passwordPolicy.ReminderBefore - s.passwordPolicy.MaxAge)
+
+	users, err := s.userRepo.FindPasswordExpiring(ctx, changedBefore)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, user := range users {
+		reminderData := &services.PasswordExpiryReminderData{
+			Name:              user.Name,
+			ExpiresAt:         user.PasswordExpiresAt(s.passwordPolicy.MaxAge).Format(utils.TimeFormat),
+			ChangePasswordURL: getChangePasswordURL(),
+		}
+		if err := s.emailService.SendPasswordExpiryReminder(user.Email, reminderData); err != nil {
+			log.Printf("failed to send password expiry reminder: %v", err)
+			continue
+		}
+
+		user.PasswordReminderSentAt = &now
+		if _, err := s.userRepo.Update(ctx, user); err != nil {
+			log.Printf("failed to record password expiry reminder of user %s: %v", user.ID, err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ListPendingApprovals returns the self-registered users that verified their
+// email and are waiting for an admin decision.
+func (s *UserService) ListPendingApprovals(ctx context.Context, page, pageSize int) (*services.UserPaginationResponse, error) {
+	offset := (page - 1) * pageSize
+	users, total, err := s.userRepo.FindByApprovalStatus(ctx, entity.ApprovalPending, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatUserPage(s.fileStorage, users, total, page, pageSize), nil
+}
+
+func (s *UserService) ApproveUser(ctx context.Context, adminID, userID uuid.UUID) (*services.UserInfo, error) {
+	user, err := s.decideApproval(ctx, adminID, userID, entity.ApprovalApproved, "")
+	if err != nil {
+		return nil, err
+	}
+
+	go func(email string, name string) {
+		approvedData := &services.RegistrationApprovedData{
+			Name:     name,
+			LoginURL: getLoginURL(),
+		}
+
+		if err := s.emailService.SendRegistrationApprovedEmail(email, approvedData); err != nil {
+			log.Printf("failed to send registration approved email: %v", err)
+		}
+	}(user.Email, user.Name)
+
+	return formatUserInfo(s.fileStorage, user), nil
+}
+
+func (s *UserService) RejectUser(ctx context.Context, adminID, userID uuid.UUID, reason string) (*services.UserInfo, error) {
+	user, err := s.decideApproval(ctx, adminID, userID, entity.ApprovalRejected, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	go func(email string, name string, reason string) {
+		rejectedData := &services.RegistrationRejectedData{
+			Name:   name,
+			Reason: reason,
+		}
+
+		if err := s.emailService.SendRegistrationRejectedEmail(email, rejectedData); err != nil {
+			log.Printf("failed to send registration rejected email: %v", err)
+		}
+	}(user.Email, user.Name, reason)
+
+	return formatUserInfo(s.fileStorage, user), nil
+}
+
+func (s *UserService) decideApproval(ctx context.Context, adminID, userID uuid.UUID, status, reason string) (*entity.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if user.ApprovalStatus != entity.ApprovalPending {
+		return nil, errors.ErrNotPendingApproval
+	}
+
+	now := time.Now()
+	user.ApprovalStatus = status
+	user.ApprovalReason = reason
+	user.ApprovalDecidedBy = &adminID
+	user.ApprovalDecidedAt = &now
+
+	// A rejected registration is deactivated rather than deleted so the email
+	// address cannot simply be registered again.
+	accountStatus := entity.UserStatusActive
+	if status == entity.ApprovalRejected {
+		accountStatus = entity.UserStatusDeactivated
+	}
+	if err := user.TransitionTo(accountStatus, reason, now); err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, errors.ErrUpdateUser
+	}
+
+	return updatedUser, nil
+}
+
+// SuspendUser blocks the user from signing in and revokes their refresh
+// tokens so existing sessions end once their access token expires.
+func (s *UserService) SuspendUser(ctx context.Context, adminID, userID uuid.UUID, req *services.SuspendUserRequest) (_ *services.UserInfo, err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminUserSuspended, adminID, userID, req.Reason, err) }()
+
+	// Keeps an admin from locking themselves, possibly the last admin, out.
+	if adminID == userID {
+		return nil, errors.ErrSuspendSelf
+	}
+
+	now := time.Now()
+	if req.Until != nil && !req.Until.After(now) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := user.Suspend(req.Reason, req.Until, now); err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, errors.ErrUpdateUser
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.invitationRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	go func(email string, name string, reason string, until *time.Time) {
+		suspendedData := &services.AccountSuspendedData{
+			Name:   name,
+			Reason: reason,
+		}
+		if until != nil {
+			suspendedData.Until = until.Format(utils.TimeFormat)
+		}
+
+		if err := s.emailService.SendAccountSuspendedEmail(email, suspendedData); err != nil {
+			log.Printf("failed to send account suspended email: %v", err)
+		}
+	}(user.Email, user.Name, req.Reason, req.Until)
+
+	return formatUserInfo(s.fileStorage, updatedUser), nil
+}
+
+func (s *UserService) UnsuspendUser(ctx context.Context, adminID, userID uuid.UUID) (_ *services.UserInfo, err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminUserUnsuspended, adminID, userID, "", err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if user.Status != entity.UserStatusSuspended {
+		return nil, errors.ErrUserNotSuspended
+	}
+
+	return s.reinstate(ctx, user)
+}
+
+// LiftExpiredSuspensions reactivates every user whose suspension has run out.
+// A user that cannot be reactivated does not hold up the others; their errors
+// are returned together.
+func (s *UserService) LiftExpiredSuspensions(ctx context.Context) error {
+	users, err := s.userRepo.FindExpiredSuspensions(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, user := range users {
+		if _, err := s.reinstate(ctx, user); err != nil {
+			log.Printf("failed to lift suspension of user %s: %v", user.ID, err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reinstate activates the user. Only users who lost access are emailed about
+// it; accounts activated for the first time get no reinstatement notice.
+func (s *UserService) reinstate(ctx context.Context, user *entity.User) (*services.UserInfo, error) {
+	previousStatus := user.Status
+	if err := user.TransitionTo(entity.UserStatusActive, "", time.Now()); err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, errors.ErrUpdateUser
+	}
+
+	switch previousStatus {
+	case entity.UserStatusSuspended, entity.UserStatusDeactivated:
+	default:
+		return formatUserInfo(s.fileStorage, updatedUser), nil
+	}
+
+	go func(email string, name string) {
+		reinstatedData := &services.AccountReinstatedData{
+			Name:     name,
+			LoginURL: getLoginURL(),
+		}
+
+		if err := s.emailService.SendAccountReinstatedEmail(email, reinstatedData); err != nil {
+			log.Printf("failed to send account reinstated email: %v", err)
+		}
+	}(user.Email, user.Name)
+
+	return formatUserInfo(s.fileStorage, updatedUser), nil
+}
+
+// audit records an action an admin took on a user. On success reason
+// describes the action; on failure it is replaced by the error.
+func (s *UserService) audit(ctx context.Context, eventType string, adminID, userID uuid.UUID, reason string, err error) {
+	record := &services.AuditRecord{
+		EventType: eventType,
+		Outcome:   entity.AuditOutcomeSuccess,
+		ActorID:   &adminID,
+		TargetID:  &userID,
+		Reason:    reason,
+	}
+	if err != nil {
+		record.Outcome = entity.AuditOutcomeFailure
+		record.Reason = err.Error()
+	}
+
+	s.auditService.Record(ctx, record)
+}
+
+// UpdateUserByAdmin applies UpdateUser on behalf of an admin and records
+// which fields were changed.
+func (s *UserService) UpdateUserByAdmin(ctx context.Context, adminID, userID uuid.UUID, req *services.UpdateUserRequest) (_ *services.UserInfo, err error) {
+	var fields []string
+	if req.Name != nil {
+		fields = append(fields, "name")
+	}
+	if req.Username != nil {
+		fields = append(fields, "username")
+	}
+	for _, key := range slices.Sorted(maps.Keys(req.Attributes)) {
+		fields = append(fields, "attributes."+key)
+	}
+	reason := ""
+	if len(fields) > 0 {
+		reason = "updated " + strings.Join(fields, ", ")
+	}
+	defer func() { s.audit(ctx, entity.AuditEventAdminUserUpdated, adminID, userID, reason, err) }()
+
+	return s.updateUser(ctx, userID, req, true)
+}
+
+// ResetUserPassword makes the user choose a new password: their sessions are
+// revoked, signing in with the current password only grants a password change
+// token, and a reset link is emailed in case they no longer know it.
+func (s *UserService) ResetUserPassword(ctx context.Context, adminID, userID uuid.UUID) (err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminPasswordReset, adminID, userID, "", err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.ErrUserNotFound
+	}
+
+	// Invited users have no password yet; they set one when accepting.
+	if _, err := s.invitationRepo.FindPendingByUserID(ctx, userID); err == nil {
+		return errors.ErrInvitationPending
+	}
+
+	token, err := generateEmailToken(s.encryptor, emailTokenResetPassword, user.Email, resetPasswordTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	user.MustChangePassword = true
+	if _, err := s.userRepo.Update(ctx, user); err != nil {
+		return errors.ErrUpdateUser
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	go func(email string, token string) {
+		resetPasswordData := &services.ResetPasswordData{
+			ResetLink: getResetPasswordURL(token),
+		}
+		if err := s.emailService.SendRequestResetPassword(email, resetPasswordData); err != nil {
+			log.Printf("failed to send reset password email: %v", err)
+		}
+	}(user.Email, token)
+
+	return nil
+}
+
+// ResendVerification sends a new verification link to a user who has not
+// verified their email yet.
+func (s *UserService) ResendVerification(ctx context.Context, adminID, userID uuid.UUID) (err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminVerificationResent, adminID, userID, "", err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.ErrUserNotFound
+	}
+
+	// Invited users verify their email by accepting the invitation.
+	if _, err := s.invitationRepo.FindPendingByUserID(ctx, userID); err == nil {
+		return errors.ErrInvitationPending
+	}
+
+	if user.Status != entity.UserStatusPendingVerification || user.ApprovalStatus != "" {
+		return errors.ErrUserVerified
+	}
+
+	token, err := generateEmailToken(s.encryptor, emailTokenVerifyEmail, user.Email, verifyEmailTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	go func(email string, token string) {
+		verifyEmailData := &services.VerifyEmailData{
+			VerificationURL: getVerifyEmailURL(token),
+		}
+		if err := s.emailService.SendVerifyEmail(email, verifyEmailData); err != nil {
+			log.Printf("failed to send verification email: %v", err)
+		}
+	}(user.Email, token)
+
+	return nil
+}
+
+// ActivateUser lets a user sign in again, or for the first time without
+// verifying their email. Registrations awaiting or refused approval are
+// decided through the approval workflow instead.
+func (s *UserService) ActivateUser(ctx context.Context, adminID, userID uuid.UUID) (_ *services.UserInfo, err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminUserActivated, adminID, userID, "", err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	switch user.ApprovalStatus {
+	case entity.ApprovalPending:
+		return nil, errors.ErrApprovalPending
+	case entity.ApprovalRejected:
+		return nil, errors.ErrRegistrationRejected
+	}
+
+	if _, err := s.invitationRepo.FindPendingByUserID(ctx, userID); err == nil {
+		return nil, errors.ErrInvitationPending
+	}
+
+	return s.reinstate(ctx, user)
+}
+
+// DeactivateUser blocks the user from signing in until an admin activates
+// them again and revokes their refresh tokens.
+func (s *UserService) DeactivateUser(ctx context.Context, adminID, userID uuid.UUID, reason string) (_ *services.UserInfo, err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminUserDeactivated, adminID, userID, reason, err) }()
+
+	// Keeps an admin from locking themselves, possibly the last admin, out.
+	if adminID == userID {
+		return nil, errors.ErrDeactivateSelf
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := user.TransitionTo(entity.UserStatusDeactivated, reason, time.Now()); err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, errors.ErrUpdateUser
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.invitationRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return formatUserInfo(s.fileStorage, updatedUser), nil
+}
+
+// DeleteUser soft deletes the user and revokes their refresh tokens. The
+// account can be restored until the retention period ends.
+func (s *UserService) DeleteUser(ctx context.Context, adminID, userID uuid.UUID) (err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminUserDeleted, adminID, userID, "", err) }()
+
+	// Keeps an admin from locking themselves, possibly the last admin, out.
+	if adminID == userID {
+		return errors.ErrDeleteSelf
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return errors.ErrUserNotFound
+	}
+
 	if err := s.userRepo.Delete(ctx, userID); err != nil {
 		return errors.ErrDeleteUser
 	}
+
+	return s.refreshTokenRepo.RevokeAllByUserID(ctx, userID)
+}
+
+func (s *UserService) RestoreUser(ctx context.Context, adminID, userID uuid.UUID) (_ *services.UserInfo, err error) {
+	defer func() { s.audit(ctx, entity.AuditEventAdminUserRestored, adminID, userID, "", err) }()
+
+	if _, err := s.userRepo.FindDeletedByID(ctx, userID); err != nil {
+		if _, err := s.userRepo.FindByID(ctx, userID); err == nil {
+			return nil, errors.ErrUserNotDeleted
+		}
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := s.userRepo.Restore(ctx, userID); err != nil {
+		return nil, errors.ErrUpdateUser
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	return formatUserInfo(s.fileStorage, user), nil
+}
+
+// PurgeExpiredUsernameAliases releases old usernames whose alias ran out.
+func (s *UserService) PurgeExpiredUsernameAliases(ctx context.Context) error {
+	deleted, err := s.userRepo.DeleteExpiredUsernameAliases(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		log.Printf("purged %d expired username aliases", deleted)
+	}
+
+	return nil
+}
+
+// PurgeDeletedUsers permanently removes users deleted longer ago than the
+// retention period, along with their avatars.
+func (s *UserService) PurgeDeletedUsers(ctx context.Context) error {
+	if s.deletionConfig.Retention <= 0 {
+		return nil
+	}
+
+	users, err := s.userRepo.Purge(ctx, time.Now().Add(-s.deletionConfig.Retention))
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if user.AvatarKey != "" && s.fileStorage != nil {
+			deleteThumbnails(ctx, s.fileStorage, user.AvatarKey)
+		}
+	}
+
+	if len(users) > 0 {
+		log.Printf("purged %d deleted users", len(users))
+	}
+
 	return nil
 }
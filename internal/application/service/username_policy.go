@@ -0,0 +1,43 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+)
+
+type UsernamePolicy struct {
+	reserved       map[string]struct{}
+	changeCooldown time.Duration
+	aliasRetention time.Duration
+}
+
+func NewUsernamePolicy(cfg config.UsernamePolicyConfig) services.UsernamePolicy {
+	reserved := make(map[string]struct{}, len(cfg.Reserved))
+	for _, username := range cfg.Reserved {
+		if username = strings.ToLower(strings.TrimSpace(username)); username != "" {
+			reserved[username] = struct{}{}
+		}
+	}
+
+	return &UsernamePolicy{
+		reserved:       reserved,
+		changeCooldown: cfg.ChangeCooldown,
+		aliasRetention: cfg.AliasRetention,
+	}
+}
+
+func (p *UsernamePolicy) IsReserved(username string) bool {
+	_, ok := p.reserved[strings.ToLower(username)]
+	return ok
+}
+
+func (p *UsernamePolicy) NextChangeAt(changedAt time.Time) time.Time {
+	return changedAt.Add(p.changeCooldown)
+}
+
+func (p *UsernamePolicy) AliasRetention() time.Duration {
+	return p.aliasRetention
+}
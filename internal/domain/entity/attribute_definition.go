@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	// AttributeTypeDate values are calendar dates in YYYY-MM-DD form.
+	AttributeTypeDate = "date"
+)
+
+var AttributeTypes = []string{
+	AttributeTypeString,
+	AttributeTypeNumber,
+	AttributeTypeBoolean,
+	AttributeTypeDate,
+}
+
+// AttributeDefinition describes a custom attribute admins allow on users.
+// Key and Type are fixed once created; Pattern only applies to strings.
+type AttributeDefinition struct {
+	ID       uuid.UUID
+	Key      string
+	Label    string
+	Type     string
+	Required bool
+	Unique   bool
+	Pattern  string
+
+	AuditInfo
+}
+
+// UserAttributes holds custom attribute values keyed by definition key and
+// is stored as a JSON object.
+type UserAttributes map[string]any
+
+func (a UserAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (a *UserAttributes) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, a)
+	case string:
+		return json.Unmarshal([]byte(value), a)
+	default:
+		return fmt.Errorf("cannot scan %T into UserAttributes", src)
+	}
+}
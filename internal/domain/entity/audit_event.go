@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditEventLogin                  = "auth.login"
+	AuditEventLogout                 = "auth.logout"
+	AuditEventTokenRefresh           = "auth.token_refresh"
+	AuditEventVerificationRequested  = "auth.verification_requested"
+	AuditEventEmailVerified          = "auth.email_verified"
+	AuditEventPasswordResetRequested = "auth.password_reset_requested"
+	AuditEventPasswordReset          = "auth.password_reset"
+	AuditEventInvitationAccepted     = "auth.invitation_accepted"
+	AuditEventAccountSecured         = "auth.account_secured"
+)
+
+// Admin events are recorded with the admin as actor and the user acted on as
+// target.
+const (
+	AuditEventAdminUserUpdated        = "admin.user_updated"
+	AuditEventAdminPasswordReset      = "admin.password_reset"
+	AuditEventAdminVerificationResent = "admin.verification_resent"
+	AuditEventAdminUserActivated      = "admin.user_activated"
+	AuditEventAdminUserDeactivated    = "admin.user_deactivated"
+	AuditEventAdminUserSuspended      = "admin.user_suspended"
+	AuditEventAdminUserUnsuspended    = "admin.user_unsuspended"
+	AuditEventAdminUserDeleted        = "admin.user_deleted"
+	AuditEventAdminUserRestored       = "admin.user_restored"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditEvent is an append-only record of a security relevant action. ActorID
+// is the user who performed it, TargetID the user it was performed on; either
+// is nil when unknown.
+type AuditEvent struct {
+	ID        uuid.UUID
+	ActorID   *uuid.UUID
+	TargetID  *uuid.UUID
+	EventType string
+	Outcome   string
+	Reason    string
+	IPAddress string
+	UserAgent string
+	RequestID string
+	CreatedAt time.Time
+}
@@ -9,4 +9,7 @@ type AuditInfo struct {
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 	IsDeleted bool
+	// Version starts at 1 and goes up with every write, so updates made
+	// from a stale copy can be detected.
+	Version int
 }
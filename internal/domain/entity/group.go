@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Group is a team of users. Groups nest through ParentID, and members of a
+// subgroup count as members of every group above it.
+type Group struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	ParentID    *uuid.UUID
+
+	AuditInfo
+}
+
+// GroupMember records that a user was added to a group directly.
+type GroupMember struct {
+	GroupID   uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
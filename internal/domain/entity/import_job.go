@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+)
+
+const (
+	ImportStatusQueued    = "queued"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+const (
+	// ImportRowValid marks a row that passed validation in a dry run.
+	ImportRowValid   = "valid"
+	ImportRowCreated = "created"
+	ImportRowFailed  = "failed"
+)
+
+// ImportJob tracks a bulk user import. The counters are updated while the
+// job runs so progress can be polled.
+type ImportJob struct {
+	ID              uuid.UUID
+	CreatedBy       *uuid.UUID
+	Format          string
+	DryRun          bool
+	SendInvitations bool
+	Status          string
+	Total           int
+	Processed       int
+	Succeeded       int
+	Failed          int
+	Error           string
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+
+	AuditInfo
+}
+
+// ImportJobRow is the outcome of a single row of an import. Row is the
+// 1-based position of the record in the uploaded file.
+type ImportJobRow struct {
+	ID             uuid.UUID
+	ImportJobID    uuid.UUID
+	Row            int
+	Email          string
+	Name           string
+	Status         string
+	UserID         *uuid.UUID
+	InvitationSent bool
+	Error          string
+	CreatedAt      time.Time
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KnownDevice is a client a user has successfully signed in from before.
+type KnownDevice struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Fingerprint string
+	IPAddress   string
+	UserAgent   string
+	Location    string
+	LastSeenAt  time.Time
+	User        User
+
+	AuditInfo
+}
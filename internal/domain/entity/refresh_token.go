@@ -7,12 +7,15 @@ import (
 )
 
 type RefreshToken struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Token     string
-	ExpiresAt time.Time
-	IsRevoked bool
-	User      User
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	Token             string
+	ExpiresAt         time.Time
+	IsRevoked         bool
+	IPAddress         string
+	UserAgent         string
+	ClientFingerprint string
+	User              User
 
 	AuditInfo
 }
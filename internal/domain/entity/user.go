@@ -1,16 +1,105 @@
 package entity
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// AvatarSizes are the square thumbnail sizes, in pixels, stored for every
+// avatar.
+var AvatarSizes = []int{64, 128, 256}
+
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
+
 type User struct {
 	ID       uuid.UUID
 	Email    string
 	Username string
 	Password string
 	Name     string
-	IsActive bool
+	Role     string
+	// UsernameChangedAt is nil until the username is first changed.
+	UsernameChangedAt *time.Time
+	// AvatarKey is the storage prefix of the avatar thumbnails; empty when
+	// the user has no avatar.
+	AvatarKey string
+	// Attributes are the values of admin-defined custom attributes.
+	Attributes UserAttributes
+
+	// Status is one of the UserStatus constants and only changes through
+	// TransitionTo.
+	Status          string
+	StatusReason    string
+	StatusChangedAt *time.Time
+	// SuspendedUntil is when a suspension lifts; nil while suspended means
+	// indefinitely.
+	SuspendedUntil *time.Time
+
+	PasswordChangedAt      *time.Time
+	MustChangePassword     bool
+	PasswordReminderSentAt *time.Time
+	// FirstLoginPending is set on new accounts and cleared by their first
+	// sign-in. Accounts created before it existed never have it set.
+	FirstLoginPending bool
+
+	// ApprovalStatus is empty for accounts that never went through the
+	// admin approval workflow.
+	ApprovalStatus    string
+	ApprovalReason    string
+	ApprovalDecidedBy *uuid.UUID
+	ApprovalDecidedAt *time.Time
 
 	AuditInfo
 }
+
+// AvatarObjectKey returns the storage key of the thumbnail of the given size
+// under an avatar key.
+func AvatarObjectKey(avatarKey string, size int) string {
+	return fmt.Sprintf("%s/%d.png", avatarKey, size)
+}
+
+// PasswordExpiresAt returns when the current password expires under the given
+// maximum age, or nil when passwords never expire.
+func (u *User) PasswordExpiresAt(maxAge time.Duration) *time.Time {
+	if maxAge <= 0 {
+		return nil
+	}
+
+	changedAt := u.CreatedAt
+	if u.PasswordChangedAt != nil {
+		changedAt = *u.PasswordChangedAt
+	}
+
+	expiresAt := changedAt.Add(maxAge)
+	return &expiresAt
+}
+
+// RequiresPasswordChange reports whether the user has to choose a new
+// password before being granted a full session.
+func (u *User) RequiresPasswordChange(maxAge time.Duration, now time.Time) bool {
+	if u.MustChangePassword {
+		return true
+	}
+
+	expiresAt := u.PasswordExpiresAt(maxAge)
+	return expiresAt != nil && now.After(*expiresAt)
+}
+
+// SetPassword stores a new password hash and resets the rotation state.
+func (u *User) SetPassword(hashedPassword string, now time.Time) {
+	u.Password = hashedPassword
+	u.PasswordChangedAt = &now
+	u.MustChangePassword = false
+	u.PasswordReminderSentAt = nil
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLocale   = "en"
+	DefaultTimezone = "UTC"
+)
+
+// Locales are the locales email templates are available in.
+var Locales = []string{"en", "id"}
+
+const (
+	DateFormatISO = "YYYY-MM-DD"
+	DateFormatDMY = "DD/MM/YYYY"
+	DateFormatMDY = "MM/DD/YYYY"
+)
+
+var DateFormats = []string{
+	DateFormatISO,
+	DateFormatDMY,
+	DateFormatMDY,
+}
+
+// Email notification categories users can opt out of. Security emails such as
+// verification links, password resets, password expiry reminders and new
+// sign-in alerts have no category and are always sent.
+const (
+	// NotificationAccount covers approval decisions and account status
+	// changes made by admins.
+	NotificationAccount = "account"
+)
+
+var NotificationCategories = []string{
+	NotificationAccount,
+}
+
+// UserPreferences holds per-user display and notification settings. Users
+// that never saved any get DefaultUserPreferences.
+type UserPreferences struct {
+	UserID     uuid.UUID
+	Locale     string
+	Timezone   string
+	DateFormat string
+	// EmailNotifications records opt-ins and opt-outs by category; missing
+	// categories are opted in.
+	EmailNotifications NotificationSettings
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func DefaultUserPreferences(userID uuid.UUID) *UserPreferences {
+	return &UserPreferences{
+		UserID:             userID,
+		Locale:             DefaultLocale,
+		Timezone:           DefaultTimezone,
+		DateFormat:         DateFormatISO,
+		EmailNotifications: NotificationSettings{},
+	}
+}
+
+// WantsEmail reports whether the user accepts emails of the given category.
+func (p *UserPreferences) WantsEmail(category string) bool {
+	enabled, ok := p.EmailNotifications[category]
+	return !ok || enabled
+}
+
+// NotificationSettings maps notification categories to whether they are
+// enabled and is stored as a JSON object.
+type NotificationSettings map[string]bool
+
+func (s NotificationSettings) Value() (driver.Value, error) {
+	if s == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (s *NotificationSettings) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, s)
+	case string:
+		return json.Unmarshal([]byte(value), s)
+	default:
+		return fmt.Errorf("cannot scan %T into NotificationSettings", src)
+	}
+}
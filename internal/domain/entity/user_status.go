@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"time"
+
+	"go-gin-hexagonal/pkg/errors"
+)
+
+const (
+	UserStatusPendingVerification = "pending_verification"
+	UserStatusActive              = "active"
+	UserStatusSuspended           = "suspended"
+	UserStatusDeactivated         = "deactivated"
+)
+
+// UserStatuses lists every account status in lifecycle order.
+var UserStatuses = []string{
+	UserStatusPendingVerification,
+	UserStatusActive,
+	UserStatusSuspended,
+	UserStatusDeactivated,
+}
+
+// userStatusTransitions maps each status to the statuses it may move to.
+var userStatusTransitions = map[string][]string{
+	UserStatusPendingVerification: {UserStatusActive, UserStatusDeactivated},
+	UserStatusActive:              {UserStatusSuspended, UserStatusDeactivated},
+	UserStatusSuspended:           {UserStatusActive, UserStatusDeactivated},
+	UserStatusDeactivated:         {UserStatusActive},
+}
+
+// IsActive reports whether the user may sign in.
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
+
+// CanTransitionTo reports whether the account may move from its current
+// status to status.
+func (u *User) CanTransitionTo(status string) bool {
+	for _, allowed := range userStatusTransitions[u.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the account to status, recording why and when.
+func (u *User) TransitionTo(status, reason string, now time.Time) error {
+	if !u.CanTransitionTo(status) {
+		return errors.ErrInvalidStatusTransition
+	}
+
+	u.Status = status
+	u.StatusReason = reason
+	u.StatusChangedAt = &now
+	u.SuspendedUntil = nil
+	return nil
+}
+
+// Suspend blocks sign-in until the given time, or indefinitely when until is
+// nil. Suspending an already suspended account replaces its reason and expiry.
+func (u *User) Suspend(reason string, until *time.Time, now time.Time) error {
+	if u.Status == UserStatusSuspended {
+		u.StatusReason = reason
+		u.StatusChangedAt = &now
+	} else if err := u.TransitionTo(UserStatusSuspended, reason, now); err != nil {
+		return err
+	}
+
+	u.SuspendedUntil = until
+	return nil
+}
+
+// SuspensionExpired reports whether the account is suspended and the
+// suspension has run out.
+func (u *User) SuspensionExpired(now time.Time) bool {
+	return u.Status == UserStatusSuspended && u.SuspendedUntil != nil && !now.Before(*u.SuspendedUntil)
+}
+
+// StatusError returns the error explaining why a user in the current status
+// cannot sign in, or nil when they can.
+func (u *User) StatusError() error {
+	switch u.Status {
+	case UserStatusActive:
+		return nil
+	case UserStatusSuspended:
+		return errors.ErrAccountSuspended
+	case UserStatusDeactivated:
+		if u.ApprovalStatus == ApprovalRejected {
+			return errors.ErrRegistrationRejected
+		}
+		return errors.ErrAccountDeactivated
+	default:
+		if u.ApprovalStatus == ApprovalPending {
+			return errors.ErrApprovalPending
+		}
+		return errors.ErrUserNotVerified
+	}
+}
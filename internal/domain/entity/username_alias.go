@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsernameAlias is a username a user gave up. Until it expires lookups by the
+// old username still resolve to the user and nobody else can take it.
+type UsernameAlias struct {
+	Username  string
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+type CaptchaResult struct {
+	Success bool
+	// RiskScore ranges from 0 (human) to 1 (bot).
+	RiskScore float64
+}
+
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (*CaptchaResult, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import "io"
+
+// RecordWriter encodes rows of a tabular export. Records have one value per
+// header column.
+type RecordWriter interface {
+	Write(record []string) error
+	// Flush pushes buffered records to the underlying writer.
+	Flush() error
+	// Close writes any trailer the format needs and flushes.
+	Close() error
+}
+
+type Exporter interface {
+	// NewWriter starts an export in the given format and writes the header.
+	NewWriter(w io.Writer, format string, header []string) (RecordWriter, error)
+}
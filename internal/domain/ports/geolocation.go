@@ -0,0 +1,8 @@
+package ports
+
+type GeoLocator interface {
+	// Locate returns a human readable approximate location such as
+	// "Berlin, Germany", or an empty string when the IP cannot be resolved.
+	Locate(ip string) string
+	Close() error
+}
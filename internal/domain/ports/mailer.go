@@ -1,6 +1,8 @@
 package ports
 
 type MailerManager interface {
-	LoadEmailTemplate(templateName string, data any) (string, error)
+	// LoadEmailTemplate renders the named template in the given locale,
+	// falling back to the default templates when no translation exists.
+	LoadEmailTemplate(templateName string, locale string, data any) (string, error)
 	SendEmail(to string, subject string, body string) error
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type AttributeDefinitionRepository interface {
+	Create(ctx context.Context, definition *entity.AttributeDefinition) (*entity.AttributeDefinition, error)
+	Update(ctx context.Context, definition *entity.AttributeDefinition) (*entity.AttributeDefinition, error)
+	// Delete removes the definition and its values from every user.
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error)
+	FindByKey(ctx context.Context, key string) (*entity.AttributeDefinition, error)
+	// FindAll returns every definition ordered by key.
+	FindAll(ctx context.Context) ([]*entity.AttributeDefinition, error)
+}
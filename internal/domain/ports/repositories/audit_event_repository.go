@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEventFilter narrows down audit events; zero values match everything.
+type AuditEventFilter struct {
+	ActorID   *uuid.UUID
+	TargetID  *uuid.UUID
+	EventType string
+	Outcome   string
+	From      *time.Time
+	To        *time.Time
+}
+
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *entity.AuditEvent) error
+	FindAll(ctx context.Context, filter *AuditEventFilter, limit, offset int) ([]*entity.AuditEvent, int64, error)
+	DeleteOlderThan(ctx context.Context, before time.Time) (int64, error)
+}
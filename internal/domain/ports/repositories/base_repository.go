@@ -2,13 +2,38 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// CursorQuery selects one page of keyset pagination. Rows are ordered by
+// Column, which must not be nullable, with id breaking ties. An empty Cursor
+// starts at the first page.
+type CursorQuery struct {
+	Cursor string
+	Limit  int
+	Column string
+	Desc   bool
+	// IncludeDeleted also returns soft deleted rows.
+	IncludeDeleted bool
+}
+
+// CursorPage holds one page of keyset pagination. A cursor is empty when
+// there are no more rows in that direction.
+type CursorPage[T any] struct {
+	Items      []*T
+	NextCursor string
+	PrevCursor string
+}
+
+// BaseRepository soft deletes entities that embed entity.AuditInfo: Delete
+// only flags the row and the finders skip flagged rows. Other entities are
+// deleted outright.
 type BaseRepository[T any] interface {
 	Raw(ctx context.Context, query string) ([]*T, error)
 	FindAll(ctx context.Context, limit, offset int, query any, args ...any) ([]*T, int64, error)
+	FindPage(ctx context.Context, page *CursorQuery, query any, args ...any) (*CursorPage[T], error)
 	FindByID(ctx context.Context, id uuid.UUID) (*T, error)
 	FindFirst(ctx context.Context, query any, args ...any) (*T, error)
 	Where(ctx context.Context, query any, args ...any) ([]*T, error)
@@ -16,4 +41,9 @@ type BaseRepository[T any] interface {
 	Create(ctx context.Context, entity *T) (*T, error)
 	Update(ctx context.Context, entity *T) (*T, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*T, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently removes rows soft deleted before deletedBefore and
+	// returns them.
+	Purge(ctx context.Context, deletedBefore time.Time) ([]*T, error)
 }
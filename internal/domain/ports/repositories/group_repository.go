@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type GroupRepository interface {
+	Create(ctx context.Context, group *entity.Group) (*entity.Group, error)
+	Update(ctx context.Context, group *entity.Group) (*entity.Group, error)
+	// Delete removes the group and its memberships. Groups that still have
+	// subgroups cannot be deleted.
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Group, error)
+	FindByName(ctx context.Context, name string) (*entity.Group, error)
+	// FindAll returns every group ordered by name.
+	FindAll(ctx context.Context) ([]*entity.Group, error)
+	// FindDescendantIDs returns the ids of every group nested below id, at
+	// any depth.
+	FindDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// FindByUser returns the groups userID was added to along with every
+	// group above them, ordered by name.
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Group, error)
+
+	AddMember(ctx context.Context, member *entity.GroupMember) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+	FindMember(ctx context.Context, groupID, userID uuid.UUID) (*entity.GroupMember, error)
+	// FindMembers pages through the users added to the group, and to its
+	// subgroups when nested is set. Deleted users are skipped.
+	FindMembers(ctx context.Context, groupID uuid.UUID, nested bool, limit, offset int) ([]*entity.User, int64, error)
+}
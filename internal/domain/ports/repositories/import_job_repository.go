@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error)
+	Update(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportJob, error)
+	CreateRows(ctx context.Context, rows []*entity.ImportJobRow) error
+	// FindRows returns the job's rows in file order.
+	FindRows(ctx context.Context, jobID uuid.UUID, limit, offset int) ([]*entity.ImportJobRow, int64, error)
+}
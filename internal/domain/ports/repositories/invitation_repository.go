@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entity.Invitation) (*entity.Invitation, error)
+	Update(ctx context.Context, invitation *entity.Invitation) (*entity.Invitation, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	FindPendingByUserID(ctx context.Context, userID uuid.UUID) (*entity.Invitation, error)
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type KnownDeviceRepository interface {
+	Create(ctx context.Context, device *entity.KnownDevice) (*entity.KnownDevice, error)
+	Update(ctx context.Context, device *entity.KnownDevice) (*entity.KnownDevice, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.KnownDevice, error)
+	DeleteAllByUserID(ctx context.Context, userID uuid.UUID) error
+}
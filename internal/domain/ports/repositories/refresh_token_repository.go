@@ -12,7 +12,9 @@ type RefreshTokenRepository interface {
 	FindByToken(ctx context.Context, token string) (*entity.RefreshToken, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.RefreshToken, error)
 	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error
-	RevokeByToken(ctx context.Context, token string) error
+	// RevokeByToken revokes the token unless it already is, returning how
+	// many tokens it revoked.
+	RevokeByToken(ctx context.Context, token string) (int64, error)
 	DeleteExpired(ctx context.Context) error
 	IsTokenValid(ctx context.Context, token string) bool
 }
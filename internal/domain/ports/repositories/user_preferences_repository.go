@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type UserPreferencesRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.UserPreferences, error)
+	// FindByEmail looks preferences up through the owning user's email.
+	FindByEmail(ctx context.Context, email string) (*entity.UserPreferences, error)
+	// Save creates the preferences or replaces the stored ones.
+	Save(ctx context.Context, preferences *entity.UserPreferences) (*entity.UserPreferences, error)
+}
@@ -3,18 +3,70 @@ package repositories
 import (
 	"context"
 	"go-gin-hexagonal/internal/domain/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// UserFilter narrows down users; zero values match everything.
+type UserFilter struct {
+	// Search matches the name, username or email by words, substring or
+	// similar spelling, ignoring case.
+	Search      string
+	Email       string
+	Status      string
+	IsActive    *bool
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	// IncludeDeleted also returns soft deleted users.
+	IncludeDeleted bool
+	// Attributes matches users whose custom attributes contain every given
+	// value; values must already have their definition's JSON type.
+	Attributes map[string]any
+}
+
+// SortOption orders results by a field the repository allows sorting on.
+// An empty Field falls back to the repository default.
+type SortOption struct {
+	Field string
+	Desc  bool
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) (*entity.User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	// FindByUsername falls back to unexpired aliases, so old usernames still
+	// find their user.
 	FindByUsername(ctx context.Context, username string) (*entity.User, error)
 	Update(ctx context.Context, user *entity.User) (*entity.User, error)
+	// Delete soft deletes the user; Restore undoes it until Purge removes
+	// the row for good.
 	Delete(ctx context.Context, id uuid.UUID) error
-	FindAll(ctx context.Context, limit, offset int, search string) ([]*entity.User, int64, error)
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) ([]*entity.User, error)
+	FindAll(ctx context.Context, filter *UserFilter, sort *SortOption, limit, offset int) ([]*entity.User, int64, error)
+	FindPage(ctx context.Context, filter *UserFilter, sort *SortOption, cursor string, limit int) (*CursorPage[entity.User], error)
 	ExistsByEmail(ctx context.Context, email string) bool
+	// ExistsByUsername also counts unexpired aliases.
 	ExistsByUsername(ctx context.Context, username string) bool
+	// ExistsByAttribute reports whether a user other than excludeID holds the
+	// given custom attribute value.
+	ExistsByAttribute(ctx context.Context, key string, value any, excludeID uuid.UUID) (bool, error)
+	// HasDuplicateAttribute reports whether two users share a value of the
+	// given custom attribute.
+	HasDuplicateAttribute(ctx context.Context, key string) (bool, error)
+	FindPasswordExpiring(ctx context.Context, changedBefore time.Time) ([]*entity.User, error)
+	FindExpiredSuspensions(ctx context.Context, now time.Time) ([]*entity.User, error)
+	// FindByApprovalStatus lists the oldest accounts first, so approval
+	// queues are first come, first served.
+	FindByApprovalStatus(ctx context.Context, status string, limit, offset int) ([]*entity.User, int64, error)
+	// SaveUsernameAlias creates the alias or hands an expired one with the
+	// same username over to the new user.
+	SaveUsernameAlias(ctx context.Context, alias *entity.UsernameAlias) error
+	DeleteUsernameAlias(ctx context.Context, username string) error
+	DeleteExpiredUsernameAliases(ctx context.Context, now time.Time) (int64, error)
 }
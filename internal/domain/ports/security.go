@@ -7,6 +7,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// TokenScopePasswordChange restricts an access token to changing the password.
+const TokenScopePasswordChange = "password_change"
+
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Verify(hashedPassword, password string) error
@@ -14,6 +17,7 @@ type PasswordHasher interface {
 
 type TokenManager interface {
 	GenerateAccessToken(user *entity.User) (string, time.Time, error)
+	GenerateScopedAccessToken(user *entity.User, scope string) (string, time.Time, error)
 	GenerateRefreshToken(userID uuid.UUID) (string, time.Time, error)
 	ValidateAccessToken(token string) (*AccessTokenClaims, error)
 	ValidateRefreshToken(token string) (*RefreshTokenClaims, error)
@@ -34,7 +38,9 @@ type AccessTokenClaims struct {
 	UserID    uuid.UUID
 	Email     string
 	Username  string
+	Role      string
 	TokenType string
+	Scope     string
 	ExpiresAt time.Time
 	IssuedAt  time.Time
 	NotBefore time.Time
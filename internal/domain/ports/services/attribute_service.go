@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttributeService manages the custom attributes admins allow on users.
+type AttributeService interface {
+	ListDefinitions(ctx context.Context) ([]*AttributeDefinitionInfo, error)
+	CreateDefinition(ctx context.Context, req *CreateAttributeDefinitionRequest) (*AttributeDefinitionInfo, error)
+	UpdateDefinition(ctx context.Context, id uuid.UUID, req *UpdateAttributeDefinitionRequest) (*AttributeDefinitionInfo, error)
+	// DeleteDefinition also removes the attribute's values from every user.
+	DeleteDefinition(ctx context.Context, id uuid.UUID) error
+}
+
+type AttributeDefinitionInfo struct {
+	ID        uuid.UUID
+	Key       string
+	Label     string
+	Type      string
+	Required  bool
+	Unique    bool
+	Pattern   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CreateAttributeDefinitionRequest struct {
+	Key   string
+	Label string
+	// Type is one of the entity.AttributeType constants.
+	Type     string
+	Required bool
+	Unique   bool
+	// Pattern is a regular expression string values must match. Like JSON
+	// Schema patterns it is not implicitly anchored.
+	Pattern string
+}
+
+// UpdateAttributeDefinitionRequest changes the given fields; key and type
+// cannot change once values exist.
+type UpdateAttributeDefinitionRequest struct {
+	Label    *string
+	Required *bool
+	Unique   *bool
+	Pattern  *string
+}
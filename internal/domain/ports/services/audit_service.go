@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditService interface {
+	// Record stores an audit event. Failures are logged rather than returned
+	// so that auditing never breaks the action being audited.
+	Record(ctx context.Context, record *AuditRecord)
+	ListEvents(ctx context.Context, req *ListAuditEventsRequest) (*AuditEventPaginationResponse, error)
+	PruneExpired(ctx context.Context) error
+}
+
+// AuditRecord is the part of an audit event supplied by the caller; request
+// details such as IP address and request ID are taken from the context.
+type AuditRecord struct {
+	EventType string
+	Outcome   string
+	ActorID   *uuid.UUID
+	TargetID  *uuid.UUID
+	Reason    string
+}
+
+type ListAuditEventsRequest struct {
+	Page      int
+	PageSize  int
+	ActorID   *uuid.UUID
+	TargetID  *uuid.UUID
+	EventType string
+	Outcome   string
+	From      *time.Time
+	To        *time.Time
+}
+
+type AuditEventInfo struct {
+	ID        uuid.UUID
+	ActorID   *uuid.UUID
+	TargetID  *uuid.UUID
+	EventType string
+	Outcome   string
+	Reason    string
+	IPAddress string
+	UserAgent string
+	RequestID string
+	CreatedAt time.Time
+}
+
+type AuditEventPaginationResponse struct {
+	Datas      []*AuditEventInfo
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
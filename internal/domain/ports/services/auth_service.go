@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,12 +10,17 @@ import (
 type AuthService interface {
 	Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error)
 	Register(ctx context.Context, req *RegisterRequest) error
-	RefreshToken(ctx context.Context, refreshToken string) (*RefreshTokenResponse, error)
+	RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*RefreshTokenResponse, error)
 	Logout(ctx context.Context, userID uuid.UUID) error
+	// LogoutWithRefreshToken ends every session of the user the refresh
+	// token belongs to.
+	LogoutWithRefreshToken(ctx context.Context, refreshToken string) error
 	VerifyEmail(ctx context.Context, token string) error
 	SendVerifyEmail(ctx context.Context, email string) error
 	SendResetPassword(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
+	AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) error
+	SecureAccount(ctx context.Context, token string) error
 }
 
 type RegisterRequest struct {
@@ -24,19 +30,41 @@ type RegisterRequest struct {
 	Name     string
 }
 
+// ClientInfo describes the client a request originates from.
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
 type LoginRequest struct {
 	Email    string
 	Password string
+	Client   ClientInfo
 }
 
+// LoginResponse carries only a restricted access token and no refresh token
+// while PasswordChangeRequired is set.
 type LoginResponse struct {
-	AccessToken  string
+	AccessToken            string
+	AccessTokenExpiresAt   time.Time
+	RefreshToken           string
+	RefreshTokenExpiresAt  time.Time
+	PasswordChangeRequired bool
+}
+
+type RefreshTokenRequest struct {
 	RefreshToken string
+	Client       ClientInfo
 }
 
+// RefreshTokenResponse, like LoginResponse, carries only a restricted access
+// token while PasswordChangeRequired is set.
 type RefreshTokenResponse struct {
-	AccessToken  string
-	RefreshToken string
+	AccessToken            string
+	AccessTokenExpiresAt   time.Time
+	RefreshToken           string
+	RefreshTokenExpiresAt  time.Time
+	PasswordChangeRequired bool
 }
 
 type ResetPasswordRequest struct {
@@ -44,3 +72,9 @@ type ResetPasswordRequest struct {
 	NewPassword     string
 	ConfirmPassword string
 }
+
+type AcceptInvitationRequest struct {
+	Token           string
+	NewPassword     string
+	ConfirmPassword string
+}
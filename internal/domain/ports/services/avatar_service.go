@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type AvatarService interface {
+	// UploadAvatar replaces the user's avatar with thumbnails of the image.
+	// The format is detected from the content, not the declared type.
+	UploadAvatar(ctx context.Context, userID uuid.UUID, image io.Reader) (*UserInfo, error)
+	DeleteAvatar(ctx context.Context, userID uuid.UUID) (*UserInfo, error)
+}
@@ -1,14 +1,21 @@
 package services
 
 type EmailService interface {
-	SendNewUserEmail(to string, data *NewUserEmailData) error
+	SendInvitationEmail(to string, data *InvitationEmailData) error
 	SendVerifyEmail(to string, data *VerifyEmailData) error
 	SendRequestResetPassword(to string, data *ResetPasswordData) error
+	SendPasswordExpiryReminder(to string, data *PasswordExpiryReminderData) error
+	SendNewSignInEmail(to string, data *NewSignInEmailData) error
+	SendRegistrationApprovedEmail(to string, data *RegistrationApprovedData) error
+	SendRegistrationRejectedEmail(to string, data *RegistrationRejectedData) error
+	SendAccountSuspendedEmail(to string, data *AccountSuspendedData) error
+	SendAccountReinstatedEmail(to string, data *AccountReinstatedData) error
 }
 
-type NewUserEmailData struct {
-	Email    string
-	Password string
+type InvitationEmailData struct {
+	Name          string
+	InvitationURL string
+	ExpiresAt     string
 }
 
 type VerifyEmailData struct {
@@ -18,3 +25,40 @@ type VerifyEmailData struct {
 type ResetPasswordData struct {
 	ResetLink string
 }
+
+type PasswordExpiryReminderData struct {
+	Name              string
+	ExpiresAt         string
+	ChangePasswordURL string
+}
+
+type NewSignInEmailData struct {
+	Name             string
+	SignInTime       string
+	IPAddress        string
+	UserAgent        string
+	Location         string
+	SecureAccountURL string
+}
+
+type RegistrationApprovedData struct {
+	Name     string
+	LoginURL string
+}
+
+type RegistrationRejectedData struct {
+	Name   string
+	Reason string
+}
+
+type AccountSuspendedData struct {
+	Name   string
+	Reason string
+	// Until is empty for indefinite suspensions.
+	Until string
+}
+
+type AccountReinstatedData struct {
+	Name     string
+	LoginURL string
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"io"
+)
+
+type ExportService interface {
+	// ExportUsers streams every user matching the request to w. Nothing is
+	// written if the request is rejected, but an error after that leaves a
+	// truncated export.
+	ExportUsers(ctx context.Context, req *ExportUsersRequest, w io.Writer) error
+}
+
+type ExportUsersRequest struct {
+	// Format is csv, ndjson or xlsx.
+	Format string
+	// Filters and sorting match GetAllUsers; pagination fields are ignored.
+	ListUsersRequest
+}
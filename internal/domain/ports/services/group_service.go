@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GroupService manages teams of users. Membership is inherited upwards: a
+// member of a subgroup also belongs to every group above it.
+type GroupService interface {
+	ListGroups(ctx context.Context) ([]*GroupInfo, error)
+	GetGroup(ctx context.Context, id uuid.UUID) (*GroupInfo, error)
+	CreateGroup(ctx context.Context, req *CreateGroupRequest) (*GroupInfo, error)
+	UpdateGroup(ctx context.Context, id uuid.UUID, req *UpdateGroupRequest) (*GroupInfo, error)
+	// DeleteGroup refuses to delete a group that still has subgroups.
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+
+	ListMembers(ctx context.Context, groupID uuid.UUID, req *ListGroupMembersRequest) (*UserPaginationResponse, error)
+	AddMember(ctx context.Context, groupID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+
+	// ListUserGroups returns every group userID belongs to, including those
+	// inherited through subgroups.
+	ListUserGroups(ctx context.Context, userID uuid.UUID) ([]*GroupInfo, error)
+	// IsMember reports whether userID belongs to at least one of the named
+	// groups, directly or through a subgroup.
+	IsMember(ctx context.Context, userID uuid.UUID, names ...string) (bool, error)
+}
+
+type GroupInfo struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	ParentID    *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CreateGroupRequest struct {
+	Name        string
+	Description string
+	ParentID    *uuid.UUID
+}
+
+// UpdateGroupRequest changes the given fields. A ParentID of uuid.Nil moves
+// the group to the top level.
+type UpdateGroupRequest struct {
+	Name        *string
+	Description *string
+	ParentID    *uuid.UUID
+}
+
+type ListGroupMembersRequest struct {
+	Page     int
+	PageSize int
+	// Nested also lists the members of every subgroup.
+	Nested bool
+}
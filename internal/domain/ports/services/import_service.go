@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportService interface {
+	// StartImport parses the file, queues a job and processes it in the
+	// background; poll GetImport for progress.
+	StartImport(ctx context.Context, req *ImportRequest) (*ImportJobInfo, error)
+	// RunImport parses and processes the file before returning.
+	RunImport(ctx context.Context, req *ImportRequest) (*ImportJobInfo, error)
+	GetImport(ctx context.Context, jobID uuid.UUID, page, pageSize int) (*ImportJobDetail, error)
+}
+
+type ImportRequest struct {
+	CreatedBy *uuid.UUID
+	// Format is csv (with an email,name header) or json (an array of
+	// {"email", "name"} objects).
+	Format          string
+	File            io.Reader
+	DryRun          bool
+	SendInvitations bool
+}
+
+type ImportJobInfo struct {
+	ID              uuid.UUID
+	CreatedBy       *uuid.UUID
+	Format          string
+	DryRun          bool
+	SendInvitations bool
+	Status          string
+	Total           int
+	Processed       int
+	Succeeded       int
+	Failed          int
+	Error           string
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	CreatedAt       time.Time
+}
+
+type ImportRowInfo struct {
+	Row            int
+	Email          string
+	Name           string
+	Status         string
+	UserID         *uuid.UUID
+	InvitationSent bool
+	Error          string
+}
+
+type ImportJobDetail struct {
+	Job        *ImportJobInfo
+	Rows       []*ImportRowInfo
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PreferencesService manages per-user locale, timezone and notification
+// settings.
+type PreferencesService interface {
+	// GetPreferences returns the defaults for users that never saved any.
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*PreferencesInfo, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req *UpdatePreferencesRequest) (*PreferencesInfo, error)
+}
+
+type PreferencesInfo struct {
+	Locale             string
+	Timezone           string
+	DateFormat         string
+	EmailNotifications map[string]bool
+	UpdatedAt          time.Time
+}
+
+// UpdatePreferencesRequest changes the given fields. EmailNotifications is
+// merged into the stored settings by category.
+type UpdatePreferencesRequest struct {
+	Locale             *string
+	Timezone           *string
+	DateFormat         *string
+	EmailNotifications map[string]bool
+}
@@ -0,0 +1,9 @@
+package services
+
+type RegistrationPolicy interface {
+	// CheckEmail reports whether a new account may be created for email.
+	CheckEmail(email string) error
+	// RequiresApproval reports whether verified self-registrations must be
+	// approved by an admin before they can sign in.
+	RequiresApproval() bool
+}
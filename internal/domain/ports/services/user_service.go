@@ -8,32 +8,110 @@ import (
 )
 
 type UserService interface {
-	GetAllUsers(ctx context.Context, page, pageSize int, search string) (*UserPaginationResponse, error)
+	GetAllUsers(ctx context.Context, req *ListUsersRequest) (*UserPaginationResponse, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*UserInfo, error)
+	// GetUserByUsername also resolves old usernames that are still kept as
+	// aliases; the returned user then has a different username.
+	GetUserByUsername(ctx context.Context, username string) (*UserInfo, error)
 	CreateUser(ctx context.Context, req *CreateUserRequest) (*UserInfo, error)
+	ValidateNewUser(ctx context.Context, req *CreateUserRequest) error
+	InviteUser(ctx context.Context, userID uuid.UUID) error
 	UpdateUser(ctx context.Context, userID uuid.UUID, req *UpdateUserRequest) (*UserInfo, error)
 	ChangePassword(ctx context.Context, userID uuid.UUID, req *ChangePasswordRequest) error
-	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	DeleteUser(ctx context.Context, adminID, userID uuid.UUID) error
+	RestoreUser(ctx context.Context, adminID, userID uuid.UUID) (*UserInfo, error)
+	PurgeDeletedUsers(ctx context.Context) error
+	PurgeExpiredUsernameAliases(ctx context.Context) error
+	ResendInvitation(ctx context.Context, userID uuid.UUID) error
+	RevokeInvitation(ctx context.Context, userID uuid.UUID) error
+	SendPasswordExpiryReminders(ctx context.Context) error
+	ListPendingApprovals(ctx context.Context, page, pageSize int) (*UserPaginationResponse, error)
+	ApproveUser(ctx context.Context, adminID, userID uuid.UUID) (*UserInfo, error)
+	RejectUser(ctx context.Context, adminID, userID uuid.UUID, reason string) (*UserInfo, error)
+	SuspendUser(ctx context.Context, adminID, userID uuid.UUID, req *SuspendUserRequest) (*UserInfo, error)
+	UnsuspendUser(ctx context.Context, adminID, userID uuid.UUID) (*UserInfo, error)
+	LiftExpiredSuspensions(ctx context.Context) error
+	UpdateUserByAdmin(ctx context.Context, adminID, userID uuid.UUID, req *UpdateUserRequest) (*UserInfo, error)
+	ResetUserPassword(ctx context.Context, adminID, userID uuid.UUID) error
+	ResendVerification(ctx context.Context, adminID, userID uuid.UUID) error
+	ActivateUser(ctx context.Context, adminID, userID uuid.UUID) (*UserInfo, error)
+	DeactivateUser(ctx context.Context, adminID, userID uuid.UUID, reason string) (*UserInfo, error)
 }
 
 type UserInfo struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      string
-	IsActive  bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              uuid.UUID
+	Email           string
+	Username        string
+	Name            string
+	Role            string
+	Status          string
+	StatusReason    string
+	StatusChangedAt *time.Time
+	SuspendedUntil  *time.Time
+	// ApprovalStatus is empty unless the account went through admin approval.
+	ApprovalStatus string
+	// AvatarURLs maps thumbnail sizes in pixels to URLs; nil without an
+	// avatar.
+	AvatarURLs map[string]string
+	// Attributes holds the values of admin-defined custom attributes.
+	Attributes map[string]any
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// DeletedAt is set on soft deleted users, which are only listed on
+	// request.
+	DeletedAt *time.Time
+	// Version goes up with every change to the user.
+	Version int
+}
+
+type ListUsersRequest struct {
+	Page     int
+	PageSize int
+	// Cursor switches to keyset pagination when set; an empty cursor selects
+	// the first page. Page is ignored in that mode.
+	Cursor *string
+	// IncludeDeleted also lists soft deleted users.
+	IncludeDeleted bool
+	Search         string
+	Sort           string
+	Order          string
+	Email          string
+	Status         string
+	IsActive       *bool
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	UpdatedFrom    *time.Time
+	UpdatedTo      *time.Time
+	// Attributes filters on custom attribute values given as text; each is
+	// parsed according to its definition's type.
+	Attributes map[string]string
 }
 
 type CreateUserRequest struct {
 	Email string
 	Name  string
+	// SkipInvitation leaves the invitation to the caller, e.g. bulk imports
+	// that invite separately or not at all.
+	SkipInvitation bool
+	// Attributes are custom attribute values as decoded from JSON.
+	Attributes map[string]any
 }
 
 type UpdateUserRequest struct {
 	Name     *string
 	Username *string
+	// Attributes are merged into the existing values; a nil value removes
+	// the attribute.
+	Attributes map[string]any
+	// Versions, when set, makes the update fail with ErrVersionConflict
+	// unless the user is still at one of those versions.
+	Versions []int
+}
+
+type SuspendUserRequest struct {
+	Reason string
+	// Until is when the suspension lifts; nil suspends indefinitely.
+	Until *time.Time
 }
 
 type ChangePasswordRequest struct {
@@ -41,10 +119,14 @@ type ChangePasswordRequest struct {
 	NewPassword     string
 }
 
+// UserPaginationResponse carries either offset pagination (Page, Total,
+// TotalPages) or keyset pagination (NextCursor, PrevCursor).
 type UserPaginationResponse struct {
 	Datas      []*UserInfo
 	Total      int64
 	Page       int
 	PageSize   int
 	TotalPages int
+	NextCursor string
+	PrevCursor string
 }
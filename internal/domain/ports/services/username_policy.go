@@ -0,0 +1,15 @@
+package services
+
+import "time"
+
+type UsernamePolicy interface {
+	// IsReserved reports whether username is kept back from users, ignoring
+	// case.
+	IsReserved(username string) bool
+	// NextChangeAt returns when a username last changed at changedAt may be
+	// changed again.
+	NextChangeAt(changedAt time.Time) time.Time
+	// AliasRetention is how long a replaced username keeps resolving to its
+	// user; zero releases it right away.
+	AliasRetention() time.Duration
+}
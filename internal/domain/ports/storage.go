@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+type FileStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Delete removes an object; deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns where clients can fetch the object. Private stores return
+	// a time-limited signed URL.
+	URL(key string) string
+}
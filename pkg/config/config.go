@@ -4,15 +4,30 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Mailer   MailerConfig
-	AES      AESConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	Seed         SeedConfig
+	JWT          JWTConfig
+	Mailer       MailerConfig
+	AES          AESConfig
+	Cookie       CookieConfig
+	Invitation   InvitationConfig
+	Password     PasswordPolicyConfig
+	GeoIP        GeoIPConfig
+	Audit        AuditConfig
+	Deletion     DeletionConfig
+	Captcha      CaptchaConfig
+	Registration RegistrationPolicyConfig
+	Username     UsernamePolicyConfig
+	Import       ImportConfig
+	Export       ExportConfig
+	Storage      StorageConfig
+	Avatar       AvatarConfig
 }
 
 type ServerConfig struct {
@@ -34,6 +49,13 @@ type DatabaseConfig struct {
 	TimeZone string
 }
 
+// SeedConfig describes the admin account created by the seeders. No admin is
+// seeded while AdminPassword is empty.
+type SeedConfig struct {
+	AdminEmail    string
+	AdminPassword string
+}
+
 type JWTConfig struct {
 	AccessTokenSecret  string
 	RefreshTokenSecret string
@@ -54,6 +76,131 @@ type AESConfig struct {
 	IV  string
 }
 
+type InvitationConfig struct {
+	Expiry time.Duration
+}
+
+type PasswordPolicyConfig struct {
+	// MaxAge is how long a password stays valid; zero disables expiry.
+	MaxAge         time.Duration
+	ReminderBefore time.Duration
+	// ChangeOnFirstLogin makes users choose a new password the first time
+	// they sign in. Accounts that existed before first sign-ins were tracked
+	// are not affected.
+	ChangeOnFirstLogin bool
+}
+
+type GeoIPConfig struct {
+	// DatabasePath points at a local MaxMind City database (.mmdb).
+	DatabasePath string
+}
+
+type AuditConfig struct {
+	// Retention is how long audit events are kept; zero keeps them forever.
+	Retention time.Duration
+}
+
+type DeletionConfig struct {
+	// Retention is how long soft deleted users can be restored before they
+	// are purged; zero keeps them forever.
+	Retention time.Duration
+}
+
+type CaptchaConfig struct {
+	// Provider is one of hcaptcha, turnstile, always-pass or always-fail.
+	// CAPTCHA checks are skipped when it is empty.
+	Provider string
+	Secret   string
+	SiteKey  string
+	// VerifyURL overrides the provider's siteverify endpoint.
+	VerifyURL string
+	// RiskThreshold rejects verifications whose risk score (0 safe, 1 bot)
+	// is above it. Providers without risk scoring always report 0.
+	RiskThreshold float64
+	Timeout       time.Duration
+}
+
+type RegistrationPolicyConfig struct {
+	// AllowedDomains restricts new accounts to these email domains and their
+	// subdomains. An empty list allows every domain.
+	AllowedDomains  []string
+	BlockedDomains  []string
+	BlockDisposable bool
+	// RequireApproval holds self-registered accounts in a pending state after
+	// email verification until an admin approves them.
+	RequireApproval bool
+}
+
+type UsernamePolicyConfig struct {
+	// Reserved usernames cannot be registered or changed to, ignoring case.
+	Reserved []string
+	// ChangeCooldown is how long users must wait between username changes;
+	// zero allows changing it at any time.
+	ChangeCooldown time.Duration
+	// AliasRetention is how long an old username keeps resolving to its user
+	// and stays unavailable to others; zero releases it right away.
+	AliasRetention time.Duration
+}
+
+type ImportConfig struct {
+	// MaxRows caps how many users a single bulk import may contain.
+	MaxRows int
+	// MaxFileSize is the largest upload accepted, in bytes.
+	MaxFileSize int64
+}
+
+type ExportConfig struct {
+	// BatchSize is how many rows are read from the database at a time.
+	BatchSize int
+	// AllowedGroups limits exports to admins in one of these groups,
+	// including through subgroups; empty lets every admin export.
+	AllowedGroups []string
+}
+
+type StorageConfig struct {
+	// Driver is local or s3.
+	Driver string
+	// PublicURL prefixes object keys to build URLs. For the local driver its
+	// path is also where the API serves the files (default /uploads); for s3
+	// signed URLs are issued when it is empty.
+	PublicURL string
+	LocalDir  string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	// S3PathStyle addresses buckets as endpoint/bucket, as MinIO expects.
+	S3PathStyle bool
+	// URLExpiry is how long signed URLs stay valid.
+	URLExpiry time.Duration
+}
+
+type AvatarConfig struct {
+	// MaxFileSize is the largest upload accepted, in bytes.
+	MaxFileSize int64
+	// MaxDimension rejects images wider or taller than this many pixels
+	// before they are decoded.
+	MaxDimension int
+}
+
+type CookieConfig struct {
+	Enabled          bool
+	Domain           string
+	Secure           bool
+	SameSite         string
+	AccessTokenName  string
+	RefreshTokenName string
+	RefreshTokenPath string
+	CSRFKey          string
+}
+
+var defaultReservedUsernames = []string{
+	"admin", "administrator", "root", "support", "system", "security",
+	"help", "staff", "moderator", "api", "www", "null", "undefined",
+}
+
 func Load() (*Config, error) {
 	config := &Config{
 		Server: ServerConfig{
@@ -71,6 +218,10 @@ func Load() (*Config, error) {
 			DBName:   getEnv("DB_NAME", "go_gin_hexagonal"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
+		Seed: SeedConfig{
+			AdminEmail:    getEnv("SEED_ADMIN_EMAIL", "admin@example.com"),
+			AdminPassword: getEnv("SEED_ADMIN_PASSWORD", ""),
+		},
 		JWT: JWTConfig{
 			AccessTokenSecret:  getEnv("JWT_ACCESS_SECRET", "your-access-secret-key"),
 			RefreshTokenSecret: getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key"),
@@ -88,6 +239,76 @@ func Load() (*Config, error) {
 			Key: getEnv("AES_KEY", "your-aes-encryption-key"),
 			IV:  getEnv("AES_IV", "your-aes-initialization-vector"),
 		},
+		Cookie: CookieConfig{
+			Enabled:          getEnvAsBool("AUTH_COOKIE_ENABLED", false),
+			Domain:           getEnv("AUTH_COOKIE_DOMAIN", ""),
+			Secure:           getEnvAsBool("AUTH_COOKIE_SECURE", true),
+			SameSite:         getEnv("AUTH_COOKIE_SAMESITE", "strict"),
+			AccessTokenName:  getEnv("AUTH_COOKIE_ACCESS_NAME", "access_token"),
+			RefreshTokenName: getEnv("AUTH_COOKIE_REFRESH_NAME", "refresh_token"),
+			RefreshTokenPath: getEnv("AUTH_COOKIE_REFRESH_PATH", "/api/v1/auth"),
+			CSRFKey:          getEnv("CSRF_KEY", ""),
+		},
+		Invitation: InvitationConfig{
+			Expiry: getEnvAsDuration("INVITATION_EXPIRY", 72*time.Hour),
+		},
+		Password: PasswordPolicyConfig{
+			MaxAge:             time.Duration(getEnvAsInt("PASSWORD_MAX_AGE_DAYS", 0)) * 24 * time.Hour,
+			ReminderBefore:     time.Duration(getEnvAsInt("PASSWORD_REMINDER_DAYS", 7)) * 24 * time.Hour,
+			ChangeOnFirstLogin: getEnvAsBool("PASSWORD_CHANGE_ON_FIRST_LOGIN", false),
+		},
+		GeoIP: GeoIPConfig{
+			DatabasePath: getEnv("GEOIP_DB_PATH", ""),
+		},
+		Audit: AuditConfig{
+			Retention: time.Duration(getEnvAsInt("AUDIT_RETENTION_DAYS", 90)) * 24 * time.Hour,
+		},
+		Deletion: DeletionConfig{
+			Retention: time.Duration(getEnvAsInt("DELETED_USER_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		},
+		Captcha: CaptchaConfig{
+			Provider:      getEnv("CAPTCHA_PROVIDER", ""),
+			Secret:        getEnv("CAPTCHA_SECRET", ""),
+			SiteKey:       getEnv("CAPTCHA_SITE_KEY", ""),
+			VerifyURL:     getEnv("CAPTCHA_VERIFY_URL", ""),
+			RiskThreshold: getEnvAsFloat("CAPTCHA_RISK_THRESHOLD", 0.5),
+			Timeout:       getEnvAsDuration("CAPTCHA_TIMEOUT", 5*time.Second),
+		},
+		Registration: RegistrationPolicyConfig{
+			AllowedDomains:  getEnvAsSlice("REGISTRATION_ALLOWED_DOMAINS", nil),
+			BlockedDomains:  getEnvAsSlice("REGISTRATION_BLOCKED_DOMAINS", nil),
+			BlockDisposable: getEnvAsBool("REGISTRATION_BLOCK_DISPOSABLE", true),
+			RequireApproval: getEnvAsBool("REGISTRATION_REQUIRE_APPROVAL", false),
+		},
+		Username: UsernamePolicyConfig{
+			Reserved:       getEnvAsSlice("USERNAME_RESERVED", defaultReservedUsernames),
+			ChangeCooldown: time.Duration(getEnvAsInt("USERNAME_CHANGE_COOLDOWN_DAYS", 30)) * 24 * time.Hour,
+			AliasRetention: time.Duration(getEnvAsInt("USERNAME_ALIAS_RETENTION_DAYS", 90)) * 24 * time.Hour,
+		},
+		Import: ImportConfig{
+			MaxRows:     getEnvAsInt("IMPORT_MAX_ROWS", 5000),
+			MaxFileSize: int64(getEnvAsInt("IMPORT_MAX_FILE_SIZE_MB", 5)) << 20,
+		},
+		Export: ExportConfig{
+			BatchSize:     getEnvAsInt("EXPORT_BATCH_SIZE", 500),
+			AllowedGroups: getEnvAsSlice("EXPORT_ALLOWED_GROUPS", nil),
+		},
+		Storage: StorageConfig{
+			Driver:      getEnv("STORAGE_DRIVER", "local"),
+			PublicURL:   getEnv("STORAGE_PUBLIC_URL", ""),
+			LocalDir:    getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+			S3Endpoint:  getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+			S3Region:    getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:    getEnv("S3_BUCKET", ""),
+			S3AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey: getEnv("S3_SECRET_KEY", ""),
+			S3PathStyle: getEnvAsBool("S3_PATH_STYLE", false),
+			URLExpiry:   getEnvAsDuration("STORAGE_URL_EXPIRY", 1*time.Hour),
+		},
+		Avatar: AvatarConfig{
+			MaxFileSize:  int64(getEnvAsInt("AVATAR_MAX_FILE_SIZE_MB", 2)) << 20,
+			MaxDimension: getEnvAsInt("AVATAR_MAX_DIMENSION", 4096),
+		},
 	}
 
 	return config, nil
@@ -123,6 +344,40 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsSlice reads a comma separated list, ignoring empty entries.
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,16 @@
+package errors
+
+// AttributeError names the custom attribute a validation error is about. Err
+// is one of the attribute sentinel errors.
+type AttributeError struct {
+	Key string
+	Err error
+}
+
+func (e *AttributeError) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e *AttributeError) Unwrap() error {
+	return e.Err
+}
@@ -21,6 +21,12 @@ var (
 	ErrUnexpectedSinginMethod      = errors.New("unexpected signin method")
 	ErrInvalidClaims               = errors.New("invalid claims in token")
 	ErrInvalidInput                = errors.New("invalid input provided")
+	ErrInvalidSortField            = errors.New("invalid sort field")
+	ErrInvalidCursor               = errors.New("invalid pagination cursor")
+	ErrPasswordChangeRequired      = errors.New("password change required")
+	ErrPasswordReused              = errors.New("new password must be different from the current password")
+	ErrInsufficientPermissions     = errors.New("insufficient permissions")
+	ErrVersionConflict             = errors.New("resource was modified by another request")
 
 	// User
 	ErrUserNotFound      = errors.New("user not found")
@@ -29,4 +35,96 @@ var (
 	ErrDeleteUser        = errors.New("failed to delete user")
 	ErrCreateUser        = errors.New("failed to create user")
 	ErrUserNotVerified   = errors.New("user not verified")
+	ErrInvalidEmail      = errors.New("invalid email address")
+	ErrInvalidName       = errors.New("name must be between 3 and 100 characters")
+	ErrUserNotDeleted    = errors.New("user is not deleted")
+	ErrUserVerified      = errors.New("user is already verified")
+
+	// Account status
+	ErrAccountSuspended        = errors.New("account is suspended")
+	ErrAccountDeactivated      = errors.New("account is deactivated")
+	ErrInvalidStatusTransition = errors.New("invalid account status transition")
+	ErrUserNotSuspended        = errors.New("user is not suspended")
+	ErrDeactivateSelf          = errors.New("admins cannot deactivate their own account")
+	ErrSuspendSelf             = errors.New("admins cannot suspend their own account")
+	ErrDeleteSelf              = errors.New("admins cannot delete their own account")
+
+	// Registration policy
+	ErrEmailDomainNotAllowed = errors.New("email domain is not allowed to register")
+	ErrEmailDomainBlocked    = errors.New("email domain is blocked")
+	ErrDisposableEmail       = errors.New("disposable email addresses are not allowed")
+
+	// Username policy
+	ErrUsernameReserved       = errors.New("username is reserved")
+	ErrUsernameChangeCooldown = errors.New("username was changed too recently")
+
+	// Approval
+	ErrApprovalPending      = errors.New("account is awaiting admin approval")
+	ErrRegistrationRejected = errors.New("registration was rejected")
+	ErrNotPendingApproval   = errors.New("user is not pending approval")
+
+	// Invitation
+	ErrInvitationNotFound        = errors.New("invitation not found")
+	ErrInvitationPending         = errors.New("invitation has not been accepted yet")
+	ErrInvitationAlreadyAccepted = errors.New("invitation already accepted")
+
+	// Import
+	ErrImportJobNotFound   = errors.New("import job not found")
+	ErrInvalidImportFormat = errors.New("import format must be csv or json")
+	ErrInvalidImportFile   = errors.New("import file could not be parsed")
+	ErrImportTooLarge      = errors.New("import file exceeds the allowed size")
+	ErrImportEmpty         = errors.New("import file contains no rows")
+	ErrImportTooManyRows   = errors.New("import file exceeds the allowed number of rows")
+	ErrDuplicateImportRow  = errors.New("email appears more than once in the import file")
+
+	// Avatar
+	ErrAvatarNotFound        = errors.New("user has no avatar")
+	ErrAvatarTooLarge        = errors.New("avatar file exceeds the allowed size")
+	ErrUnsupportedAvatarType = errors.New("avatar must be a JPEG, PNG or GIF image")
+	ErrInvalidAvatarImage    = errors.New("avatar image could not be read or is too large")
+
+	// Attributes
+	ErrAttributeDefinitionNotFound = errors.New("attribute definition not found")
+	ErrAttributeDefinitionExists   = errors.New("attribute definition already exists")
+	ErrInvalidAttributeKey         = errors.New("attribute key must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	ErrInvalidAttributeType        = errors.New("attribute type must be string, number, boolean or date")
+	ErrInvalidAttributePattern     = errors.New("attribute pattern must be a valid regular expression and is only allowed on string attributes")
+	ErrAttributeHasDuplicates      = errors.New("existing users share values of this attribute")
+	ErrUnknownAttribute            = errors.New("attribute is not defined")
+	ErrAttributeRequired           = errors.New("attribute is required")
+	ErrAttributeWrongType          = errors.New("attribute value has the wrong type")
+	ErrAttributePatternMismatch    = errors.New("attribute value does not match the required pattern")
+	ErrAttributeNotUnique          = errors.New("attribute value is already taken")
+
+	// Groups
+	ErrGroupNotFound       = errors.New("group not found")
+	ErrGroupExists         = errors.New("group already exists")
+	ErrInvalidGroupName    = errors.New("group name must start with a lowercase letter or digit and contain only lowercase letters, digits, hyphens and underscores")
+	ErrInvalidGroupParent  = errors.New("group cannot be nested inside itself or one of its subgroups")
+	ErrGroupHasSubgroups   = errors.New("group still has subgroups")
+	ErrGroupMemberExists   = errors.New("user is already a member of the group")
+	ErrGroupMemberNotFound = errors.New("user is not a member of the group")
+
+	// Patch
+	ErrUnsupportedPatchType = errors.New("patch must be application/merge-patch+json or application/json-patch+json")
+	ErrInvalidPatch         = errors.New("invalid patch document")
+	ErrPatchConflict        = errors.New("patch path does not exist in the resource")
+	ErrPatchTestFailed      = errors.New("patch test operation failed")
+
+	// Preferences
+	ErrUnsupportedLocale           = errors.New("locale must be en or id")
+	ErrInvalidTimezone             = errors.New("timezone must be a valid IANA time zone name")
+	ErrInvalidDateFormat           = errors.New("date format must be YYYY-MM-DD, DD/MM/YYYY or MM/DD/YYYY")
+	ErrUnknownNotificationCategory = errors.New("notification category must be account")
+
+	// Export
+	ErrInvalidExportFormat = errors.New("export format must be csv, ndjson or xlsx")
+
+	// Captcha
+	ErrCaptchaRequired    = errors.New("captcha token required")
+	ErrCaptchaInvalid     = errors.New("captcha verification failed")
+	ErrCaptchaUnavailable = errors.New("captcha verification unavailable")
+
+	// Seeding
+	ErrSeedAdminPasswordMissing = errors.New("SEED_ADMIN_PASSWORD must be set to seed an admin account")
 )
@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+// Join wraps errors.Join for callers that import this package as errors.
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "context"
+
+type requestMetadataKey struct{}
+
+// RequestMetadata describes the HTTP request a service call originates from.
+type RequestMetadata struct {
+	RequestID string
+	IPAddress string
+	UserAgent string
+}
+
+func WithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, metadata)
+}
+
+// RequestMetadataFromContext returns the metadata stored in ctx, or an empty
+// value for calls that do not originate from an HTTP request.
+func RequestMetadataFromContext(ctx context.Context) RequestMetadata {
+	metadata, _ := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return metadata
+}
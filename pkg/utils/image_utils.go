@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"image"
+	"image/draw"
+)
+
+// SquareThumbnail center-crops img to a square and scales it to size x size.
+// Shrinking averages every source pixel under a target pixel; enlarging
+// repeats the nearest one.
+func SquareThumbnail(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	origin := image.Pt(bounds.Min.X+(bounds.Dx()-side)/2, bounds.Min.Y+(bounds.Dy()-side)/2)
+
+	src := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(src, src.Bounds(), img, origin, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := scaleSpan(y, side, size)
+		for x := 0; x < size; x++ {
+			x0, x1 := scaleSpan(x, side, size)
+
+			// RGBA is alpha-premultiplied, so averaging channels directly
+			// keeps transparent edges from bleeding dark.
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(src.Pix[offset+c])
+					}
+					offset += 4
+				}
+			}
+
+			count := (y1 - y0) * (x1 - x0)
+			offset := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// scaleSpan returns the source pixel range [start, end) covered by target
+// pixel i when scaling from srcSize to dstSize pixels. It always covers at
+// least one pixel.
+func scaleSpan(i, srcSize, dstSize int) (int, int) {
+	start := i * srcSize / dstSize
+	end := (i + 1) * srcSize / dstSize
+	if end <= start {
+		end = start + 1
+	}
+	return start, min(end, srcSize)
+}
@@ -0,0 +1,237 @@
+package test
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+	mock_external "go-gin-hexagonal/test/mock/external"
+	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttributeTestSuite struct {
+	suite.Suite
+	mockUserRepo     *mock_repository.MockUserRepository
+	attributeService services.AttributeService
+	userService      services.UserService
+	ctx              context.Context
+}
+
+func (suite *AttributeTestSuite) SetupTest() {
+	suite.mockUserRepo = mock_repository.NewMockUserRepository()
+	attributeRepo := mock_repository.NewMockAttributeDefinitionRepository(suite.mockUserRepo)
+
+	mailer := mock_external.NewMockEmailService()
+	mailer.On("SendInvitationEmail", mock.Anything, mock.Anything).Return(nil)
+
+	suite.attributeService = service.NewAttributeService(attributeRepo, suite.mockUserRepo)
+	suite.userService = newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:      suite.mockUserRepo,
+		Mailer:        mailer,
+		AttributeRepo: attributeRepo,
+	})
+	suite.ctx = context.Background()
+
+	for _, req := range []*services.CreateAttributeDefinitionRequest{
+		{Key: "department", Label: "Department", Type: entity.AttributeTypeString, Required: true},
+		{Key: "employee_id", Label: "Employee ID", Type: entity.AttributeTypeString, Unique: true, Pattern: `^E\d{4}$`},
+		{Key: "level", Label: "Level", Type: entity.AttributeTypeNumber},
+		{Key: "contractor", Label: "Contractor", Type: entity.AttributeTypeBoolean},
+		{Key: "start_date", Label: "Start date", Type: entity.AttributeTypeDate},
+	} {
+		_, err := suite.attributeService.CreateDefinition(suite.ctx, req)
+		suite.Require().NoError(err)
+	}
+}
+
+func TestAttributeTestSuite(t *testing.T) {
+	suite.Run(t, new(AttributeTestSuite))
+}
+
+func (suite *AttributeTestSuite) createUser(email string, attributes map[string]any) (*services.UserInfo, error) {
+	return suite.userService.CreateUser(suite.ctx, &services.CreateUserRequest{
+		Email:      email,
+		Name:       "Attribute User",
+		Attributes: attributes,
+	})
+}
+
+func (suite *AttributeTestSuite) requireAttributeError(err error, key string, cause error) {
+	attributeErr, ok := err.(*errors.AttributeError)
+	suite.Require().True(ok, "expected an attribute error, got %v", err)
+	suite.Equal(key, attributeErr.Key)
+	suite.Equal(cause, attributeErr.Err)
+}
+
+func (suite *AttributeTestSuite) TestDefinitionValidation() {
+	cases := []struct {
+		req *services.CreateAttributeDefinitionRequest
+		err error
+	}{
+		{&services.CreateAttributeDefinitionRequest{Key: "Team", Label: "Team", Type: entity.AttributeTypeString}, errors.ErrInvalidAttributeKey},
+		{&services.CreateAttributeDefinitionRequest{Key: "team", Label: "Team", Type: "list"}, errors.ErrInvalidAttributeType},
+		{&services.CreateAttributeDefinitionRequest{Key: "team", Label: "Team", Type: entity.AttributeTypeString, Pattern: "("}, errors.ErrInvalidAttributePattern},
+		{&services.CreateAttributeDefinitionRequest{Key: "team", Label: "Team", Type: entity.AttributeTypeNumber, Pattern: `^\d+$`}, errors.ErrInvalidAttributePattern},
+		{&services.CreateAttributeDefinitionRequest{Key: "department", Label: "Department", Type: entity.AttributeTypeString}, errors.ErrAttributeDefinitionExists},
+	}
+
+	for _, tc := range cases {
+		_, err := suite.attributeService.CreateDefinition(suite.ctx, tc.req)
+		suite.Equal(tc.err, err, tc.req.Key)
+	}
+
+	definitions, err := suite.attributeService.ListDefinitions(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Len(definitions, 5)
+	suite.Equal("contractor", definitions[0].Key)
+}
+
+func (suite *AttributeTestSuite) TestCreateUserValidatesAttributes() {
+	_, err := suite.createUser("missing@example.com", nil)
+	suite.requireAttributeError(err, "department", errors.ErrAttributeRequired)
+
+	cases := []struct {
+		attributes map[string]any
+		key        string
+		err        error
+	}{
+		{map[string]any{"department": "Sales", "team": "A"}, "team", errors.ErrUnknownAttribute},
+		{map[string]any{"department": 12.0}, "department", errors.ErrAttributeWrongType},
+		{map[string]any{"department": "Sales", "employee_id": "X1"}, "employee_id", errors.ErrAttributePatternMismatch},
+		{map[string]any{"department": "Sales", "level": "3"}, "level", errors.ErrAttributeWrongType},
+		{map[string]any{"department": "Sales", "contractor": "yes"}, "contractor", errors.ErrAttributeWrongType},
+		{map[string]any{"department": "Sales", "start_date": "01/02/2026"}, "start_date", errors.ErrAttributeWrongType},
+	}
+	for _, tc := range cases {
+		_, err := suite.createUser("invalid@example.com", tc.attributes)
+		suite.requireAttributeError(err, tc.key, tc.err)
+	}
+
+	// ValidateNewUser applies the same rules, so imports catch them too.
+	err = suite.userService.ValidateNewUser(suite.ctx, &services.CreateUserRequest{Email: "import@example.com", Name: "Import User"})
+	suite.requireAttributeError(err, "department", errors.ErrAttributeRequired)
+
+	user, err := suite.createUser("valid@example.com", map[string]any{
+		"department":  "Sales",
+		"employee_id": "E0001",
+		"level":       3.0,
+		"contractor":  false,
+		"start_date":  "2026-01-02",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Sales", user.Attributes["department"])
+	suite.Equal(3.0, user.Attributes["level"])
+	suite.Equal(false, user.Attributes["contractor"])
+
+	_, err = suite.createUser("clash@example.com", map[string]any{"department": "Sales", "employee_id": "E0001"})
+	suite.requireAttributeError(err, "employee_id", errors.ErrAttributeNotUnique)
+}
+
+func (suite *AttributeTestSuite) TestUpdateUserMergesAttributes() {
+	user, err := suite.createUser("update@example.com", map[string]any{"department": "Sales", "level": 1.0, "employee_id": "E0002"})
+	suite.Require().NoError(err)
+
+	updated, err := suite.userService.UpdateUser(suite.ctx, user.ID, &services.UpdateUserRequest{
+		Attributes: map[string]any{"level": 2.0, "contractor": true, "employee_id": "E0002"},
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Sales", updated.Attributes["department"])
+	suite.Equal(2.0, updated.Attributes["level"])
+	suite.Equal(true, updated.Attributes["contractor"])
+
+	updated, err = suite.userService.UpdateUser(suite.ctx, user.ID, &services.UpdateUserRequest{
+		Attributes: map[string]any{"level": nil},
+	})
+	suite.Require().NoError(err)
+	suite.NotContains(updated.Attributes, "level")
+
+	_, err = suite.userService.UpdateUser(suite.ctx, user.ID, &services.UpdateUserRequest{
+		Attributes: map[string]any{"department": nil},
+	})
+	suite.requireAttributeError(err, "department", errors.ErrAttributeRequired)
+
+	// Users that predate a required attribute can still be updated.
+	legacy, err := suite.mockUserRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+	name := "Legacy User"
+	_, err = suite.userService.UpdateUser(suite.ctx, legacy.ID, &services.UpdateUserRequest{Name: &name})
+	suite.NoError(err)
+
+	_, err = suite.userService.UpdateUser(suite.ctx, legacy.ID, &services.UpdateUserRequest{
+		Attributes: map[string]any{"employee_id": "E0002"},
+	})
+	suite.requireAttributeError(err, "employee_id", errors.ErrAttributeNotUnique)
+}
+
+func (suite *AttributeTestSuite) TestGetAllUsersFiltersByAttributes() {
+	_, err := suite.createUser("sales@example.com", map[string]any{"department": "Sales", "level": 2.0, "contractor": true})
+	suite.Require().NoError(err)
+	_, err = suite.createUser("support@example.com", map[string]any{"department": "Support", "level": 2.0})
+	suite.Require().NoError(err)
+
+	list := func(attributes map[string]string) ([]string, error) {
+		result, err := suite.userService.GetAllUsers(suite.ctx, &services.ListUsersRequest{Page: 1, PageSize: 10, Attributes: attributes})
+		if err != nil {
+			return nil, err
+		}
+		var emails []string
+		for _, user := range result.Datas {
+			emails = append(emails, user.Email)
+		}
+		return emails, nil
+	}
+
+	emails, err := list(map[string]string{"level": "2"})
+	suite.Require().NoError(err)
+	suite.ElementsMatch([]string{"sales@example.com", "support@example.com"}, emails)
+
+	emails, err = list(map[string]string{"level": "2", "contractor": "true"})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"sales@example.com"}, emails)
+
+	_, err = list(map[string]string{"team": "A"})
+	suite.requireAttributeError(err, "team", errors.ErrUnknownAttribute)
+
+	_, err = list(map[string]string{"level": "high"})
+	suite.requireAttributeError(err, "level", errors.ErrAttributeWrongType)
+}
+
+func (suite *AttributeTestSuite) TestUpdateAndDeleteDefinition() {
+	_, err := suite.createUser("first@example.com", map[string]any{"department": "Sales", "level": 1.0})
+	suite.Require().NoError(err)
+	_, err = suite.createUser("second@example.com", map[string]any{"department": "Sales", "level": 1.0})
+	suite.Require().NoError(err)
+
+	definitions, err := suite.attributeService.ListDefinitions(suite.ctx)
+	suite.Require().NoError(err)
+	byKey := make(map[string]*services.AttributeDefinitionInfo)
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	unique := true
+	_, err = suite.attributeService.UpdateDefinition(suite.ctx, byKey["department"].ID, &services.UpdateAttributeDefinitionRequest{Unique: &unique})
+	suite.Equal(errors.ErrAttributeHasDuplicates, err)
+
+	pattern := `^[A-Z]`
+	_, err = suite.attributeService.UpdateDefinition(suite.ctx, byKey["level"].ID, &services.UpdateAttributeDefinitionRequest{Pattern: &pattern})
+	suite.Equal(errors.ErrInvalidAttributePattern, err)
+
+	label := "Team level"
+	updated, err := suite.attributeService.UpdateDefinition(suite.ctx, byKey["level"].ID, &services.UpdateAttributeDefinitionRequest{Label: &label})
+	suite.Require().NoError(err)
+	suite.Equal("Team level", updated.Label)
+
+	suite.Require().NoError(suite.attributeService.DeleteDefinition(suite.ctx, byKey["level"].ID))
+	suite.Equal(errors.ErrAttributeDefinitionNotFound, suite.attributeService.DeleteDefinition(suite.ctx, byKey["level"].ID))
+
+	user, err := suite.mockUserRepo.FindByEmail(suite.ctx, "first@example.com")
+	suite.Require().NoError(err)
+	suite.NotContains(user.Attributes, "level")
+	suite.Equal("Sales", user.Attributes["department"])
+}
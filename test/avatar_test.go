@@ -0,0 +1,215 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"go-gin-hexagonal/internal/adapter/storage"
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+	"go-gin-hexagonal/pkg/utils"
+	mock_external "go-gin-hexagonal/test/mock/external"
+	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AvatarTestSuite struct {
+	suite.Suite
+	mockRepo      *mock_repository.MockUserRepository
+	storageDir    string
+	avatarService services.AvatarService
+	userService   services.UserService
+	user          *entity.User
+	ctx           context.Context
+}
+
+func (suite *AvatarTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.mockRepo = mock_repository.NewMockUserRepository()
+	suite.storageDir = suite.T().TempDir()
+
+	fileStorage := storage.NewLocalStorage(config.StorageConfig{LocalDir: suite.storageDir})
+	suite.avatarService = service.NewAvatarService(suite.mockRepo, fileStorage, config.AvatarConfig{MaxFileSize: 64 << 10, MaxDimension: 1000})
+	suite.userService = newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:    suite.mockRepo,
+		FileStorage: fileStorage,
+	})
+
+	user, err := suite.mockRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+	suite.user = user
+}
+
+func TestAvatarTestSuite(t *testing.T) {
+	suite.Run(t, new(AvatarTestSuite))
+}
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func (suite *AvatarTestSuite) storedThumbnail(key string) image.Config {
+	file, err := os.Open(filepath.Join(suite.storageDir, filepath.FromSlash(key)))
+	suite.Require().NoError(err)
+	defer file.Close()
+
+	cfg, format, err := image.DecodeConfig(file)
+	suite.Require().NoError(err)
+	suite.Equal("png", format)
+	return cfg
+}
+
+func (suite *AvatarTestSuite) TestUploadAvatar() {
+	info, err := suite.avatarService.UploadAvatar(suite.ctx, suite.user.ID, bytes.NewReader(encodeTestPNG(suite.T(), 300, 200)))
+	suite.Require().NoError(err)
+	suite.Require().Len(info.AvatarURLs, len(entity.AvatarSizes))
+
+	firstKey := suite.user.AvatarKey
+	suite.True(strings.HasPrefix(firstKey, "avatars/"+suite.user.ID.String()+"/"))
+	for _, size := range entity.AvatarSizes {
+		key := entity.AvatarObjectKey(firstKey, size)
+		cfg := suite.storedThumbnail(key)
+		suite.Equal(size, cfg.Width)
+		suite.Equal(size, cfg.Height)
+	}
+	suite.Equal("/uploads/"+entity.AvatarObjectKey(firstKey, 64), info.AvatarURLs["64"])
+
+	// Other user views expose the same URLs.
+	profile, err := suite.userService.GetUserByID(suite.ctx, suite.user.ID)
+	suite.Require().NoError(err)
+	suite.Equal(info.AvatarURLs, profile.AvatarURLs)
+
+	// Replacing the avatar removes the previous thumbnails.
+	_, err = suite.avatarService.UploadAvatar(suite.ctx, suite.user.ID, bytes.NewReader(encodeTestPNG(suite.T(), 50, 50)))
+	suite.Require().NoError(err)
+	suite.NotEqual(firstKey, suite.user.AvatarKey)
+	_, err = os.Stat(filepath.Join(suite.storageDir, filepath.FromSlash(entity.AvatarObjectKey(firstKey, 64))))
+	suite.True(os.IsNotExist(err))
+
+	info, err = suite.avatarService.DeleteAvatar(suite.ctx, suite.user.ID)
+	suite.Require().NoError(err)
+	suite.Nil(info.AvatarURLs)
+	suite.Empty(suite.user.AvatarKey)
+
+	_, err = suite.avatarService.DeleteAvatar(suite.ctx, suite.user.ID)
+	suite.ErrorIs(err, errors.ErrAvatarNotFound)
+}
+
+func (suite *AvatarTestSuite) TestUploadAvatarRejectsBadFiles() {
+	valid := encodeTestPNG(suite.T(), 40, 40)
+
+	cases := map[string]struct {
+		file []byte
+		err  error
+	}{
+		"not an image":      {[]byte("<html><body>hello</body></html>"), errors.ErrUnsupportedAvatarType},
+		"undecodable webp":  {append([]byte("RIFF\x00\x00\x00\x00WEBPVP8 "), make([]byte, 32)...), errors.ErrUnsupportedAvatarType},
+		"truncated png":     {valid[:len(valid)/2], errors.ErrInvalidAvatarImage},
+		"too many pixels":   {encodeTestPNG(suite.T(), 1001, 10), errors.ErrInvalidAvatarImage},
+		"file too large":    {append(append([]byte{}, valid...), make([]byte, 64<<10)...), errors.ErrAvatarTooLarge},
+		"empty upload body": {nil, errors.ErrUnsupportedAvatarType},
+	}
+
+	for name, tc := range cases {
+		suite.Run(name, func() {
+			_, err := suite.avatarService.UploadAvatar(suite.ctx, suite.user.ID, bytes.NewReader(tc.file))
+			suite.ErrorIs(err, tc.err)
+			suite.Empty(suite.user.AvatarKey)
+		})
+	}
+}
+
+func TestSquareThumbnailCropsCenter(t *testing.T) {
+	// A 4x2 image with red in the middle and green at the edges crops to the
+	// red square.
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			c := color.RGBA{0, 255, 0, 255}
+			if x == 1 || x == 2 {
+				c = color.RGBA{255, 0, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	thumbnail := utils.SquareThumbnail(img, 3)
+	assert.Equal(t, image.Rect(0, 0, 3, 3), thumbnail.Bounds())
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			assert.Equal(t, color.RGBA{255, 0, 0, 255}, thumbnail.RGBAAt(x, y))
+		}
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	server := mock_external.NewMockS3Server("avatars-bucket")
+	defer server.Close()
+
+	cfg := config.StorageConfig{
+		S3Endpoint:  server.URL,
+		S3Region:    server.Region,
+		S3Bucket:    server.Bucket,
+		S3AccessKey: server.AccessKey,
+		S3SecretKey: server.SecretKey,
+		S3PathStyle: true,
+		URLExpiry:   time.Minute,
+	}
+	s3Storage, err := storage.NewS3Storage(cfg, server.Client())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := "avatars/user id/64.png"
+	require.NoError(t, s3Storage.Put(ctx, key, strings.NewReader("thumbnail"), 9, "image/png"))
+
+	object, ok := server.Object(key)
+	require.True(t, ok)
+	assert.Equal(t, "image/png", object.ContentType)
+
+	// Without a public URL clients get a presigned link.
+	resp, err := server.Client().Get(s3Storage.URL(key))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "thumbnail", string(body))
+
+	require.NoError(t, s3Storage.Delete(ctx, key))
+	_, ok = server.Object(key)
+	assert.False(t, ok)
+	assert.NoError(t, s3Storage.Delete(ctx, key))
+
+	cfg.S3SecretKey = "wrong-secret"
+	badStorage, err := storage.NewS3Storage(cfg, server.Client())
+	require.NoError(t, err)
+	assert.Error(t, badStorage.Put(ctx, key, strings.NewReader("thumbnail"), 9, "image/png"))
+
+	cfg.PublicURL = "https://cdn.example.com/"
+	publicStorage, err := storage.NewS3Storage(cfg, server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/avatars/user id/64.png", publicStorage.URL(key))
+}
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"encoding/json"
+	"go-gin-hexagonal/internal/adapter/captcha"
+	"go-gin-hexagonal/internal/adapter/http/middleware"
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/pkg/config"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+const testCaptchaSecret = "captcha-secret"
+
+type CaptchaTestSuite struct {
+	suite.Suite
+	siteVerify     *httptest.Server
+	siteVerifyBody map[string]any
+	lastForm       url.Values
+}
+
+func (suite *CaptchaTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	suite.siteVerifyBody = map[string]any{"success": true}
+	suite.siteVerify = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		suite.lastForm = r.PostForm
+
+		if suite.siteVerifyBody == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(suite.siteVerifyBody)
+	}))
+}
+
+func (suite *CaptchaTestSuite) TearDownTest() {
+	suite.siteVerify.Close()
+}
+
+func TestCaptchaTestSuite(t *testing.T) {
+	suite.Run(t, new(CaptchaTestSuite))
+}
+
+func (suite *CaptchaTestSuite) captchaConfig(provider string) config.CaptchaConfig {
+	return config.CaptchaConfig{
+		Provider:      provider,
+		Secret:        testCaptchaSecret,
+		VerifyURL:     suite.siteVerify.URL,
+		RiskThreshold: 0.5,
+	}
+}
+
+func (suite *CaptchaTestSuite) serve(verifier ports.CaptchaVerifier, cfg config.CaptchaConfig, token string) int {
+	router := gin.New()
+	router.POST("/auth/register", middleware.NewCaptchaMiddleware(verifier, cfg).Middleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", nil)
+	if token != "" {
+		req.Header.Set("X-Captcha-Token", token)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func (suite *CaptchaTestSuite) TestStaticVerifiers() {
+	cfg := suite.captchaConfig(captcha.ProviderAlwaysPass)
+
+	disabled, err := captcha.NewCaptchaVerifier(config.CaptchaConfig{})
+	suite.NoError(err)
+	suite.Nil(disabled)
+	suite.Equal(http.StatusOK, suite.serve(disabled, cfg, ""))
+
+	pass, err := captcha.NewCaptchaVerifier(cfg)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, suite.serve(pass, cfg, "token"))
+	suite.Equal(http.StatusBadRequest, suite.serve(pass, cfg, ""))
+
+	fail, err := captcha.NewCaptchaVerifier(suite.captchaConfig(captcha.ProviderAlwaysFail))
+	suite.NoError(err)
+	suite.Equal(http.StatusForbidden, suite.serve(fail, cfg, "token"))
+
+	_, err = captcha.NewCaptchaVerifier(suite.captchaConfig("recaptcha"))
+	suite.Error(err)
+}
+
+func (suite *CaptchaTestSuite) TestHCaptchaRiskThreshold() {
+	cfg := suite.captchaConfig(captcha.ProviderHCaptcha)
+	cfg.SiteKey = "site-key"
+	verifier, err := captcha.NewCaptchaVerifier(cfg)
+	suite.Require().NoError(err)
+
+	suite.siteVerifyBody = map[string]any{"success": true, "score": 0.1}
+	suite.Equal(http.StatusOK, suite.serve(verifier, cfg, "human-token"))
+	suite.Equal(testCaptchaSecret, suite.lastForm.Get("secret"))
+	suite.Equal("human-token", suite.lastForm.Get("response"))
+	suite.Equal("site-key", suite.lastForm.Get("sitekey"))
+	suite.NotEmpty(suite.lastForm.Get("remoteip"))
+
+	// Passing the challenge is not enough when the request looks automated
+	suite.siteVerifyBody = map[string]any{"success": true, "score": 0.8}
+	suite.Equal(http.StatusForbidden, suite.serve(verifier, cfg, "bot-token"))
+
+	cfg.RiskThreshold = 0.9
+	suite.Equal(http.StatusOK, suite.serve(verifier, cfg, "bot-token"))
+
+	suite.siteVerifyBody = map[string]any{"success": false, "error-codes": []string{"invalid-input-response"}}
+	suite.Equal(http.StatusForbidden, suite.serve(verifier, cfg, "bad-token"))
+
+	// Verification fails closed when the provider is unavailable
+	suite.siteVerifyBody = nil
+	suite.Equal(http.StatusServiceUnavailable, suite.serve(verifier, cfg, "token"))
+}
+
+func (suite *CaptchaTestSuite) TestTurnstile() {
+	cfg := suite.captchaConfig(captcha.ProviderTurnstile)
+	verifier, err := captcha.NewCaptchaVerifier(cfg)
+	suite.Require().NoError(err)
+
+	suite.siteVerifyBody = map[string]any{"success": true}
+	suite.Equal(http.StatusOK, suite.serve(verifier, cfg, "token"))
+	suite.Equal(testCaptchaSecret, suite.lastForm.Get("secret"))
+
+	suite.siteVerifyBody = map[string]any{"success": false}
+	suite.Equal(http.StatusForbidden, suite.serve(verifier, cfg, "token"))
+}
@@ -0,0 +1,138 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go-gin-hexagonal/internal/adapter/export"
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+	mockRepo      *mock_repository.MockUserRepository
+	exportService services.ExportService
+	ctx           context.Context
+}
+
+func (suite *ExportTestSuite) SetupTest() {
+	suite.mockRepo = mock_repository.NewMockUserRepository()
+	// A small batch size makes every export span several pages.
+	suite.exportService = service.NewExportService(suite.mockRepo, mock_repository.NewMockAttributeDefinitionRepository(suite.mockRepo), export.NewExporter(), config.ExportConfig{BatchSize: 2})
+	suite.ctx = context.Background()
+
+	for i := 1; i <= 4; i++ {
+		status := entity.UserStatusActive
+		if i%2 == 0 {
+			status = entity.UserStatusSuspended
+		}
+		_, err := suite.mockRepo.Create(suite.ctx, &entity.User{
+			Email:    fmt.Sprintf("export%d@example.com", i),
+			Username: fmt.Sprintf("export%d", i),
+			Name:     fmt.Sprintf("Export, \"User\" %d", i),
+			Password: "hashedpassword",
+			Status:   status,
+			AuditInfo: entity.AuditInfo{
+				CreatedAt: time.Now().Add(time.Duration(i) * time.Minute),
+				UpdatedAt: time.Now(),
+			},
+		})
+		suite.Require().NoError(err)
+	}
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (suite *ExportTestSuite) exportUsers(req *services.ExportUsersRequest) []byte {
+	var buf bytes.Buffer
+	suite.Require().NoError(suite.exportService.ExportUsers(suite.ctx, req, &buf))
+	suite.NotContains(buf.String(), "hashedpassword")
+	return buf.Bytes()
+}
+
+func (suite *ExportTestSuite) TestExportCSV() {
+	output := suite.exportUsers(&services.ExportUsersRequest{
+		Format:           export.FormatCSV,
+		ListUsersRequest: services.ListUsersRequest{Sort: "email", Order: "asc"},
+	})
+
+	records, err := csv.NewReader(bytes.NewReader(output)).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 6)
+	suite.Equal("id", records[0][0])
+	suite.Equal("email", records[0][1])
+	suite.NotContains(records[0], "password")
+	suite.Equal("export1@example.com", records[1][1])
+	suite.Equal(`Export, "User" 1`, records[1][3])
+	suite.Equal(testEmail, records[5][1])
+}
+
+func (suite *ExportTestSuite) TestExportNDJSONAppliesFilters() {
+	output := suite.exportUsers(&services.ExportUsersRequest{
+		Format:           export.FormatNDJSON,
+		ListUsersRequest: services.ListUsersRequest{Status: entity.UserStatusSuspended, Sort: "email", Order: "asc"},
+	})
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	suite.Require().Len(lines, 2)
+	for i, line := range lines {
+		var record map[string]string
+		suite.Require().NoError(json.Unmarshal([]byte(line), &record))
+		suite.Equal(fmt.Sprintf("export%d@example.com", (i+1)*2), record["email"])
+		suite.Equal(entity.UserStatusSuspended, record["status"])
+		suite.NotContains(record, "password")
+	}
+}
+
+func (suite *ExportTestSuite) TestExportXLSX() {
+	output := suite.exportUsers(&services.ExportUsersRequest{Format: export.FormatXLSX})
+
+	archive, err := zip.NewReader(bytes.NewReader(output), int64(len(output)))
+	suite.Require().NoError(err)
+
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, err := file.Open()
+			suite.Require().NoError(err)
+			content, err := io.ReadAll(reader)
+			suite.Require().NoError(err)
+			sheet = string(content)
+		}
+	}
+	suite.Require().NotEmpty(sheet)
+	suite.Contains(sheet, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">email</t></is></c>`)
+	suite.Contains(sheet, `Export, &#34;User&#34; 3`)
+	suite.Equal(6, strings.Count(sheet, "<row "))
+	suite.True(strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+}
+
+func (suite *ExportTestSuite) TestExportRejectsBadRequests() {
+	var buf bytes.Buffer
+	err := suite.exportService.ExportUsers(suite.ctx, &services.ExportUsersRequest{
+		Format:           export.FormatCSV,
+		ListUsersRequest: services.ListUsersRequest{Sort: "password"},
+	}, &buf)
+	suite.ErrorIs(err, errors.ErrInvalidSortField)
+	suite.Zero(buf.Len())
+
+	err = suite.exportService.ExportUsers(suite.ctx, &services.ExportUsersRequest{Format: "pdf"}, &buf)
+	suite.ErrorIs(err, errors.ErrInvalidExportFormat)
+	suite.Zero(buf.Len())
+}
@@ -0,0 +1,193 @@
+package test
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/adapter/http/middleware"
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupTestSuite struct {
+	suite.Suite
+	mockUserRepo *mock_repository.MockUserRepository
+	groupService services.GroupService
+	ctx          context.Context
+}
+
+func (suite *GroupTestSuite) SetupTest() {
+	suite.mockUserRepo = mock_repository.NewMockUserRepository()
+	suite.groupService = service.NewGroupService(mock_repository.NewMockGroupRepository(suite.mockUserRepo), suite.mockUserRepo, nil)
+	suite.ctx = context.Background()
+}
+
+func TestGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupTestSuite))
+}
+
+func (suite *GroupTestSuite) createGroup(name string, parentID *uuid.UUID) *services.GroupInfo {
+	group, err := suite.groupService.CreateGroup(suite.ctx, &services.CreateGroupRequest{Name: name, ParentID: parentID})
+	suite.Require().NoError(err)
+	return group
+}
+
+func (suite *GroupTestSuite) createUser(email string) *entity.User {
+	user, err := suite.mockUserRepo.Create(suite.ctx, &entity.User{Email: email, Name: email, Status: entity.UserStatusActive})
+	suite.Require().NoError(err)
+	return user
+}
+
+func (suite *GroupTestSuite) TestGroupCRUD() {
+	engineering := suite.createGroup("engineering", nil)
+
+	_, err := suite.groupService.CreateGroup(suite.ctx, &services.CreateGroupRequest{Name: "engineering"})
+	suite.Equal(errors.ErrGroupExists, err)
+	_, err = suite.groupService.CreateGroup(suite.ctx, &services.CreateGroupRequest{Name: "Back End"})
+	suite.Equal(errors.ErrInvalidGroupName, err)
+	missing := uuid.New()
+	_, err = suite.groupService.CreateGroup(suite.ctx, &services.CreateGroupRequest{Name: "orphans", ParentID: &missing})
+	suite.Equal(errors.ErrGroupNotFound, err)
+
+	backend := suite.createGroup("backend", &engineering.ID)
+	suite.Equal(&engineering.ID, backend.ParentID)
+
+	name, description := "platform", "Runs the platform"
+	updated, err := suite.groupService.UpdateGroup(suite.ctx, backend.ID, &services.UpdateGroupRequest{Name: &name, Description: &description})
+	suite.Require().NoError(err)
+	suite.Equal("platform", updated.Name)
+	suite.Equal("Runs the platform", updated.Description)
+
+	groups, err := suite.groupService.ListGroups(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().Len(groups, 2)
+	suite.Equal("engineering", groups[0].Name)
+
+	// Parents with subgroups stay until the subgroups are moved or deleted.
+	suite.Equal(errors.ErrGroupHasSubgroups, suite.groupService.DeleteGroup(suite.ctx, engineering.ID))
+
+	topLevel := uuid.Nil
+	updated, err = suite.groupService.UpdateGroup(suite.ctx, backend.ID, &services.UpdateGroupRequest{ParentID: &topLevel})
+	suite.Require().NoError(err)
+	suite.Nil(updated.ParentID)
+
+	suite.Require().NoError(suite.groupService.DeleteGroup(suite.ctx, engineering.ID))
+	_, err = suite.groupService.GetGroup(suite.ctx, engineering.ID)
+	suite.Equal(errors.ErrGroupNotFound, err)
+}
+
+func (suite *GroupTestSuite) TestNestingRejectsCycles() {
+	engineering := suite.createGroup("engineering", nil)
+	backend := suite.createGroup("backend", &engineering.ID)
+	database := suite.createGroup("database", &backend.ID)
+
+	cases := []struct {
+		group  uuid.UUID
+		parent uuid.UUID
+	}{
+		{engineering.ID, engineering.ID},
+		{engineering.ID, backend.ID},
+		{engineering.ID, database.ID},
+		{backend.ID, database.ID},
+	}
+	for _, tc := range cases {
+		parent := tc.parent
+		_, err := suite.groupService.UpdateGroup(suite.ctx, tc.group, &services.UpdateGroupRequest{ParentID: &parent})
+		suite.Equal(errors.ErrInvalidGroupParent, err)
+	}
+
+	// Moving a subgroup sideways is fine.
+	_, err := suite.groupService.UpdateGroup(suite.ctx, database.ID, &services.UpdateGroupRequest{ParentID: &engineering.ID})
+	suite.NoError(err)
+}
+
+func (suite *GroupTestSuite) TestMembership() {
+	engineering := suite.createGroup("engineering", nil)
+	backend := suite.createGroup("backend", &engineering.ID)
+	sales := suite.createGroup("sales", nil)
+
+	alice := suite.createUser("alice@example.com")
+	bob := suite.createUser("bob@example.com")
+
+	suite.Require().NoError(suite.groupService.AddMember(suite.ctx, backend.ID, alice.ID))
+	suite.Require().NoError(suite.groupService.AddMember(suite.ctx, engineering.ID, bob.ID))
+	suite.Equal(errors.ErrGroupMemberExists, suite.groupService.AddMember(suite.ctx, backend.ID, alice.ID))
+	suite.Equal(errors.ErrUserNotFound, suite.groupService.AddMember(suite.ctx, backend.ID, uuid.New()))
+	suite.Equal(errors.ErrGroupNotFound, suite.groupService.AddMember(suite.ctx, uuid.New(), alice.ID))
+
+	members, err := suite.groupService.ListMembers(suite.ctx, engineering.ID, &services.ListGroupMembersRequest{Page: 1, PageSize: 10})
+	suite.Require().NoError(err)
+	suite.Require().Len(members.Datas, 1)
+	suite.Equal("bob@example.com", members.Datas[0].Email)
+
+	members, err = suite.groupService.ListMembers(suite.ctx, engineering.ID, &services.ListGroupMembersRequest{Page: 1, PageSize: 10, Nested: true})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), members.Total)
+	suite.Equal("alice@example.com", members.Datas[0].Email)
+
+	// Membership of backend is inherited by engineering.
+	groups, err := suite.groupService.ListUserGroups(suite.ctx, alice.ID)
+	suite.Require().NoError(err)
+	suite.Require().Len(groups, 2)
+	suite.Equal("backend", groups[0].Name)
+	suite.Equal("engineering", groups[1].Name)
+
+	member, err := suite.groupService.IsMember(suite.ctx, alice.ID, "engineering")
+	suite.Require().NoError(err)
+	suite.True(member)
+	member, err = suite.groupService.IsMember(suite.ctx, bob.ID, "backend", "sales")
+	suite.Require().NoError(err)
+	suite.False(member)
+
+	suite.Equal(errors.ErrGroupMemberNotFound, suite.groupService.RemoveMember(suite.ctx, sales.ID, alice.ID))
+	suite.Require().NoError(suite.groupService.RemoveMember(suite.ctx, backend.ID, alice.ID))
+
+	member, err = suite.groupService.IsMember(suite.ctx, alice.ID, "engineering", "backend")
+	suite.Require().NoError(err)
+	suite.False(member)
+}
+
+func (suite *GroupTestSuite) TestRequireGroup() {
+	engineering := suite.createGroup("engineering", nil)
+	backend := suite.createGroup("backend", &engineering.ID)
+	database := suite.createGroup("database", &backend.ID)
+	sales := suite.createGroup("sales", nil)
+
+	alice := suite.createUser("alice@example.com")
+	bob := suite.createUser("bob@example.com")
+	suite.Require().NoError(suite.groupService.AddMember(suite.ctx, database.ID, alice.ID))
+	suite.Require().NoError(suite.groupService.AddMember(suite.ctx, sales.ID, bob.ID))
+
+	gin.SetMode(gin.TestMode)
+	groupMiddleware := middleware.NewGroupMiddleware(suite.groupService)
+	request := func(userID uuid.UUID, names ...string) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) { c.Set("user_id", userID) })
+		router.GET("/", groupMiddleware.RequireGroup(names...), func(c *gin.Context) { c.Status(http.StatusOK) })
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		return recorder.Code
+	}
+
+	// Membership two levels down counts for every ancestor.
+	suite.Equal(http.StatusOK, request(alice.ID, "engineering"))
+	suite.Equal(http.StatusOK, request(alice.ID, "sales", "backend"))
+	suite.Equal(http.StatusForbidden, request(bob.ID, "engineering"))
+	suite.Equal(http.StatusForbidden, request(alice.ID, "sales"))
+	suite.Equal(http.StatusOK, request(bob.ID))
+
+	member, err := suite.groupService.IsMember(suite.ctx, alice.ID, "engineering")
+	suite.Require().NoError(err)
+	suite.True(member)
+
+	suite.Require().NoError(suite.groupService.RemoveMember(suite.ctx, database.ID, alice.ID))
+	suite.Equal(http.StatusForbidden, request(alice.ID, "engineering"))
+}
@@ -0,0 +1,223 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+	mock_external "go-gin-hexagonal/test/mock/external"
+	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+	mockUserRepo      *mock_repository.MockUserRepository
+	mockImportRepo    *mock_repository.MockImportJobRepository
+	mockAttributeRepo *mock_repository.MockAttributeDefinitionRepository
+	mockMailer        *mock_external.MockEmailService
+	importService     services.ImportService
+	ctx               context.Context
+}
+
+func (suite *ImportTestSuite) SetupTest() {
+	suite.mockUserRepo = mock_repository.NewMockUserRepository()
+	suite.mockImportRepo = mock_repository.NewMockImportJobRepository()
+	suite.mockAttributeRepo = mock_repository.NewMockAttributeDefinitionRepository(suite.mockUserRepo)
+	suite.mockMailer = mock_external.NewMockEmailService()
+
+	userService := newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:      suite.mockUserRepo,
+		Mailer:        suite.mockMailer,
+		Invitation:    config.InvitationConfig{Expiry: 72 * time.Hour},
+		Registration:  config.RegistrationPolicyConfig{BlockDisposable: true},
+		AttributeRepo: suite.mockAttributeRepo,
+	})
+	suite.importService = service.NewImportService(suite.mockImportRepo, suite.mockAttributeRepo, userService, config.ImportConfig{MaxRows: 100, MaxFileSize: 1 << 20})
+	suite.ctx = context.Background()
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
+
+func (suite *ImportTestSuite) importRows(jobID uuid.UUID) []*services.ImportRowInfo {
+	detail, err := suite.importService.GetImport(suite.ctx, jobID, 1, 100)
+	suite.Require().NoError(err)
+	return detail.Rows
+}
+
+const importCSV = `Name,Email,Department
+Jane Importer,jane.importer@example.com,Sales
+Bad Email,not-an-email,Sales
+Jane Again,JANE.IMPORTER@example.com,Sales
+Existing User,` + testEmail + `,Ops
+Al,al@example.com,Ops
+Temp Person,temp@mailinator.com,Ops
+`
+
+func (suite *ImportTestSuite) TestImportDryRun() {
+	job, err := suite.importService.RunImport(suite.ctx, &services.ImportRequest{
+		Format: entity.ImportFormatCSV,
+		File:   strings.NewReader(importCSV),
+		DryRun: true,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(entity.ImportStatusCompleted, job.Status)
+	suite.Equal(6, job.Total)
+	suite.Equal(6, job.Processed)
+	suite.Equal(1, job.Succeeded)
+	suite.Equal(5, job.Failed)
+
+	rows := suite.importRows(job.ID)
+	suite.Require().Len(rows, 6)
+	suite.Equal(entity.ImportRowValid, rows[0].Status)
+	suite.Nil(rows[0].UserID)
+	suite.Equal(errors.ErrInvalidEmail.Error(), rows[1].Error)
+	suite.Equal(errors.ErrDuplicateImportRow.Error(), rows[2].Error)
+	suite.Equal(errors.ErrUserAlreadyExists.Error(), rows[3].Error)
+	suite.Equal(errors.ErrInvalidName.Error(), rows[4].Error)
+	suite.Equal(errors.ErrDisposableEmail.Error(), rows[5].Error)
+
+	// Nothing is written in a dry run.
+	suite.False(suite.mockUserRepo.ExistsByEmail(suite.ctx, "jane.importer@example.com"))
+}
+
+func (suite *ImportTestSuite) TestImportCreatesUsersAndInvites() {
+	suite.mockMailer.On("SendInvitationEmail", "jane@example.com", mock.Anything).Return(nil)
+	suite.mockMailer.On("SendInvitationEmail", "john@example.com", mock.Anything).Return(fmt.Errorf("smtp down"))
+
+	job, err := suite.importService.RunImport(suite.ctx, &services.ImportRequest{
+		Format:          entity.ImportFormatJSON,
+		File:            strings.NewReader(`[{"email": "jane@example.com", "name": "Jane Doe"}, {"email": "john@example.com", "name": "John Doe"}, {"email": "", "name": "Nobody"}]`),
+		SendInvitations: true,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(2, job.Succeeded)
+	suite.Equal(1, job.Failed)
+
+	rows := suite.importRows(job.ID)
+	suite.Require().Len(rows, 3)
+
+	suite.Equal(entity.ImportRowCreated, rows[0].Status)
+	suite.True(rows[0].InvitationSent)
+	user, err := suite.mockUserRepo.FindByID(suite.ctx, *rows[0].UserID)
+	suite.Require().NoError(err)
+	suite.Equal("Jane Doe", user.Name)
+	suite.Equal(entity.UserStatusPendingVerification, user.Status)
+
+	// A failed invitation keeps the user and reports the delivery error.
+	suite.Equal(entity.ImportRowCreated, rows[1].Status)
+	suite.False(rows[1].InvitationSent)
+	suite.Contains(rows[1].Error, "smtp down")
+
+	suite.Equal(entity.ImportRowFailed, rows[2].Status)
+	suite.Equal(errors.ErrInvalidInput.Error(), rows[2].Error)
+
+	suite.mockMailer.AssertNumberOfCalls(suite.T(), "SendInvitationEmail", 2)
+}
+
+func (suite *ImportTestSuite) TestImportRejectsBadFiles() {
+	cases := map[string]struct {
+		format string
+		file   string
+		err    error
+	}{
+		"unknown format": {"xml", "<users/>", errors.ErrInvalidImportFormat},
+		"missing column": {entity.ImportFormatCSV, "email\njane@example.com\n", errors.ErrInvalidImportFile},
+		"header only":    {entity.ImportFormatCSV, "email,name\n", errors.ErrImportEmpty},
+		"malformed json": {entity.ImportFormatJSON, `{"email":`, errors.ErrInvalidImportFile},
+		"too many rows":  {entity.ImportFormatCSV, "email,name\n" + strings.Repeat("a@example.com,Jane Doe\n", 101), errors.ErrImportTooManyRows},
+		"too large":      {entity.ImportFormatJSON, strings.Repeat(" ", 1<<20+1), errors.ErrImportTooLarge},
+	}
+
+	for name, tc := range cases {
+		suite.Run(name, func() {
+			_, err := suite.importService.StartImport(suite.ctx, &services.ImportRequest{
+				Format: tc.format,
+				File:   strings.NewReader(tc.file),
+			})
+			suite.ErrorIs(err, tc.err)
+		})
+	}
+}
+
+func (suite *ImportTestSuite) TestStartImportRunsInBackground() {
+	job, err := suite.importService.StartImport(suite.ctx, &services.ImportRequest{
+		Format: entity.ImportFormatCSV,
+		File:   strings.NewReader("email,name\njane@example.com,Jane Doe\njohn@example.com,John Doe\n"),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(entity.ImportStatusQueued, job.Status)
+	suite.Equal(2, job.Total)
+
+	suite.Eventually(func() bool {
+		detail, err := suite.importService.GetImport(suite.ctx, job.ID, 1, 10)
+		return err == nil && detail.Job.Status == entity.ImportStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	detail, err := suite.importService.GetImport(suite.ctx, job.ID, 2, 1)
+	suite.Require().NoError(err)
+	suite.Equal(2, detail.Job.Succeeded)
+	suite.Equal(int64(2), detail.Total)
+	suite.Equal(2, detail.TotalPages)
+	suite.Require().Len(detail.Rows, 1)
+	suite.Equal("john@example.com", detail.Rows[0].Email)
+
+	_, err = suite.importService.GetImport(suite.ctx, uuid.New(), 1, 10)
+	suite.ErrorIs(err, errors.ErrImportJobNotFound)
+}
+
+func (suite *ImportTestSuite) TestImportAttributes() {
+	attributeService := service.NewAttributeService(suite.mockAttributeRepo, suite.mockUserRepo)
+	for _, req := range []*services.CreateAttributeDefinitionRequest{
+		{Key: "department", Label: "Department", Type: entity.AttributeTypeString, Required: true},
+		{Key: "employee_number", Label: "Employee number", Type: entity.AttributeTypeNumber},
+	} {
+		_, err := attributeService.CreateDefinition(suite.ctx, req)
+		suite.Require().NoError(err)
+	}
+
+	job, err := suite.importService.RunImport(suite.ctx, &services.ImportRequest{
+		Format: entity.ImportFormatCSV,
+		File: strings.NewReader("email,name,Attributes.Department,attributes.employee_number\n" +
+			"jane@example.com,Jane Doe,Sales,42\n" +
+			"john@example.com,John Doe,,7\n" +
+			"ann@example.com,Ann Doe,Ops,seven\n"),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(1, job.Succeeded)
+
+	rows := suite.importRows(job.ID)
+	suite.Require().Len(rows, 3)
+	suite.Equal(entity.ImportRowCreated, rows[0].Status)
+	user, err := suite.mockUserRepo.FindByID(suite.ctx, *rows[0].UserID)
+	suite.Require().NoError(err)
+	suite.Equal(entity.UserAttributes{"department": "Sales", "employee_number": float64(42)}, user.Attributes)
+	suite.Equal((&errors.AttributeError{Key: "department", Err: errors.ErrAttributeRequired}).Error(), rows[1].Error)
+	suite.Equal((&errors.AttributeError{Key: "employee_number", Err: errors.ErrAttributeWrongType}).Error(), rows[2].Error)
+
+	job, err = suite.importService.RunImport(suite.ctx, &services.ImportRequest{
+		Format: entity.ImportFormatJSON,
+		File: strings.NewReader(`[{"email": "mary@example.com", "name": "Mary Doe", "attributes": {"department": "Ops", "employee_number": 8}},` +
+			` {"email": "mark@example.com", "name": "Mark Doe"}]`),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(1, job.Succeeded)
+
+	rows = suite.importRows(job.ID)
+	suite.Require().Len(rows, 2)
+	user, err = suite.mockUserRepo.FindByID(suite.ctx, *rows[0].UserID)
+	suite.Require().NoError(err)
+	suite.Equal("Ops", user.Attributes["department"])
+	suite.Equal((&errors.AttributeError{Key: "department", Err: errors.ErrAttributeRequired}).Error(), rows[1].Error)
+}
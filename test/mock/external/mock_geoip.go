@@ -0,0 +1,20 @@
+package mock_external
+
+import "github.com/stretchr/testify/mock"
+
+type MockGeoLocator struct {
+	mock.Mock
+}
+
+func (m *MockGeoLocator) Locate(ip string) string {
+	args := m.Called(ip)
+	return args.String(0)
+}
+
+func (m *MockGeoLocator) Close() error {
+	return nil
+}
+
+func NewMockGeoLocator() *MockGeoLocator {
+	return &MockGeoLocator{}
+}
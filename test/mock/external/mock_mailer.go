@@ -10,8 +10,8 @@ type MockMailerManager struct {
 	mock.Mock
 }
 
-func (m *MockMailerManager) LoadEmailTemplate(templateName string) (string, error) {
-	args := m.Called(templateName)
+func (m *MockMailerManager) LoadEmailTemplate(templateName string, locale string, data any) (string, error) {
+	args := m.Called(templateName, locale, data)
 	return args.String(0), args.Error(1)
 }
 
@@ -28,7 +28,7 @@ type MockEmailService struct {
 	*MockMailerManager
 }
 
-func (m *MockEmailService) SendNewUserEmail(to string, data *services.NewUserEmailData) error {
+func (m *MockEmailService) SendInvitationEmail(to string, data *services.InvitationEmailData) error {
 	args := m.Called(to, data)
 	return args.Error(0)
 }
@@ -43,6 +43,36 @@ func (m *MockEmailService) SendRequestResetPassword(to string, data *services.Re
 	return args.Error(0)
 }
 
+func (m *MockEmailService) SendPasswordExpiryReminder(to string, data *services.PasswordExpiryReminderData) error {
+	args := m.Called(to, data)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendNewSignInEmail(to string, data *services.NewSignInEmailData) error {
+	args := m.Called(to, data)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendRegistrationApprovedEmail(to string, data *services.RegistrationApprovedData) error {
+	args := m.Called(to, data)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendRegistrationRejectedEmail(to string, data *services.RegistrationRejectedData) error {
+	args := m.Called(to, data)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendAccountSuspendedEmail(to string, data *services.AccountSuspendedData) error {
+	args := m.Called(to, data)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendAccountReinstatedEmail(to string, data *services.AccountReinstatedData) error {
+	args := m.Called(to, data)
+	return args.Error(0)
+}
+
 func NewMockEmailService() *MockEmailService {
-	return &MockEmailService{}
+	return &MockEmailService{MockMailerManager: NewMockMailerManager()}
 }
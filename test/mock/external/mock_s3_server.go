@@ -0,0 +1,186 @@
+package mock_external
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockS3Server is an in-memory, path-style S3 stand-in in the spirit of a
+// local MinIO. It verifies SigV4 header and presigned-URL signatures
+// independently of the storage adapter.
+type MockS3Server struct {
+	*httptest.Server
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+
+	mu      sync.Mutex
+	objects map[string]MockS3Object
+}
+
+type MockS3Object struct {
+	Body        []byte
+	ContentType string
+}
+
+func NewMockS3Server(bucket string) *MockS3Server {
+	server := &MockS3Server{
+		Bucket:    bucket,
+		Region:    "us-east-1",
+		AccessKey: "minio-access",
+		SecretKey: "minio-secret",
+		objects:   map[string]MockS3Object{},
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	return server
+}
+
+func (s *MockS3Server) Object(key string) (MockS3Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.objects[key]
+	return object, ok
+}
+
+func (s *MockS3Server) handle(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+s.Bucket+"/")
+	if !ok {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	if err := s.verify(r); err != nil {
+		http.Error(w, "SignatureDoesNotMatch: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = MockS3Object{Body: body, ContentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		object, exists := s.objects[key]
+		if !exists {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.ContentType)
+		w.Write(object.Body)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *MockS3Server) verify(r *http.Request) error {
+	query := r.URL.Query()
+	if signature := query.Get("X-Amz-Signature"); signature != "" {
+		return s.verifyPresigned(r, query, signature)
+	}
+
+	fields := map[string]string{}
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return fmt.Errorf("missing authorization")
+	}
+	for _, field := range strings.Split(auth, ", ") {
+		name, value, _ := strings.Cut(field, "=")
+		fields[name] = value
+	}
+
+	var headers strings.Builder
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		"",
+		headers.String(),
+		fields["SignedHeaders"],
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	return s.checkSignature(fields["Credential"], r.Header.Get("X-Amz-Date"), canonicalRequest, fields["Signature"])
+}
+
+func (s *MockS3Server) verifyPresigned(r *http.Request, query url.Values, signature string) error {
+	signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	if err != nil {
+		return err
+	}
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil || time.Now().After(signedAt.Add(time.Duration(expires)*time.Second)) {
+		return fmt.Errorf("request has expired")
+	}
+
+	query.Del("X-Amz-Signature")
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, url.QueryEscape(key)+"="+strings.ReplaceAll(url.QueryEscape(query.Get(key)), "+", "%20"))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		strings.Join(params, "&"),
+		"host:" + r.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	return s.checkSignature(query.Get("X-Amz-Credential"), query.Get("X-Amz-Date"), canonicalRequest, signature)
+}
+
+func (s *MockS3Server) checkSignature(credential, amzDate, canonicalRequest, signature string) error {
+	accessKey, scope, _ := strings.Cut(credential, "/")
+	if accessKey != s.AccessKey {
+		return fmt.Errorf("unknown access key")
+	}
+
+	date := strings.SplitN(scope, "/", 2)[0]
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + s.SecretKey)
+	for _, part := range []string{date, s.Region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+
+	if !hmac.Equal([]byte(hex.EncodeToString(key)), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
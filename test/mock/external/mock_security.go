@@ -1,6 +1,14 @@
 package mock_external
 
-import "github.com/stretchr/testify/mock"
+import (
+	"encoding/base64"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
 
 type MockSecurityService struct {
 	mock.Mock
@@ -19,3 +27,55 @@ func (m *MockSecurityService) Verify(hashedPassword, password string) error {
 func NewMockSecurityService() *MockSecurityService {
 	return &MockSecurityService{}
 }
+
+type MockTokenManager struct {
+	mock.Mock
+}
+
+func (m *MockTokenManager) GenerateAccessToken(user *entity.User) (string, time.Time, error) {
+	args := m.Called(user)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockTokenManager) GenerateScopedAccessToken(user *entity.User, scope string) (string, time.Time, error) {
+	args := m.Called(user, scope)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockTokenManager) GenerateRefreshToken(userID uuid.UUID) (string, time.Time, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockTokenManager) ValidateAccessToken(token string) (*ports.AccessTokenClaims, error) {
+	args := m.Called(token)
+	claims, _ := args.Get(0).(*ports.AccessTokenClaims)
+	return claims, args.Error(1)
+}
+
+func (m *MockTokenManager) ValidateRefreshToken(token string) (*ports.RefreshTokenClaims, error) {
+	args := m.Called(token)
+	claims, _ := args.Get(0).(*ports.RefreshTokenClaims)
+	return claims, args.Error(1)
+}
+
+func NewMockTokenManager() *MockTokenManager {
+	return &MockTokenManager{}
+}
+
+// MockEncryptor is a reversible stand-in for the AES encryptor so tests can
+// round-trip email tokens without real keys.
+type MockEncryptor struct{}
+
+func (e *MockEncryptor) Encrypt(plaintext string) (string, error) {
+	return base64.URLEncoding.EncodeToString([]byte(plaintext)), nil
+}
+
+func (e *MockEncryptor) Decrypt(ciphertext string) (string, error) {
+	plaintext, err := base64.URLEncoding.DecodeString(ciphertext)
+	return string(plaintext), err
+}
+
+func NewMockEncryptor() *MockEncryptor {
+	return &MockEncryptor{}
+}
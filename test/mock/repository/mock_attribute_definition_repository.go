@@ -0,0 +1,93 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MockAttributeDefinitionRepository struct {
+	definitions map[uuid.UUID]*entity.AttributeDefinition
+	userRepo    *MockUserRepository
+}
+
+// NewMockAttributeDefinitionRepository strips deleted attributes from the
+// users of userRepo, like the real repository does.
+func NewMockAttributeDefinitionRepository(userRepo *MockUserRepository) *MockAttributeDefinitionRepository {
+	return &MockAttributeDefinitionRepository{
+		definitions: make(map[uuid.UUID]*entity.AttributeDefinition),
+		userRepo:    userRepo,
+	}
+}
+
+func (r *MockAttributeDefinitionRepository) Create(ctx context.Context, definition *entity.AttributeDefinition) (*entity.AttributeDefinition, error) {
+	if definition.ID == uuid.Nil {
+		definition.ID = uuid.New()
+	}
+	definition.CreatedAt = time.Now()
+	definition.UpdatedAt = definition.CreatedAt
+
+	stored := *definition
+	r.definitions[definition.ID] = &stored
+	return definition, nil
+}
+
+func (r *MockAttributeDefinitionRepository) Update(ctx context.Context, definition *entity.AttributeDefinition) (*entity.AttributeDefinition, error) {
+	if _, exists := r.definitions[definition.ID]; !exists {
+		return nil, errors.ErrAttributeDefinitionNotFound
+	}
+	definition.UpdatedAt = time.Now()
+
+	stored := *definition
+	r.definitions[definition.ID] = &stored
+	return definition, nil
+}
+
+func (r *MockAttributeDefinitionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	definition, exists := r.definitions[id]
+	if !exists {
+		return errors.ErrAttributeDefinitionNotFound
+	}
+
+	for _, user := range r.userRepo.users {
+		delete(user.Attributes, definition.Key)
+	}
+	delete(r.definitions, id)
+	return nil
+}
+
+func (r *MockAttributeDefinitionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error) {
+	definition, exists := r.definitions[id]
+	if !exists {
+		return nil, errors.ErrAttributeDefinitionNotFound
+	}
+	found := *definition
+	return &found, nil
+}
+
+func (r *MockAttributeDefinitionRepository) FindByKey(ctx context.Context, key string) (*entity.AttributeDefinition, error) {
+	for _, definition := range r.definitions {
+		if definition.Key == key {
+			found := *definition
+			return &found, nil
+		}
+	}
+	return nil, errors.ErrAttributeDefinitionNotFound
+}
+
+func (r *MockAttributeDefinitionRepository) FindAll(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	definitions := make([]*entity.AttributeDefinition, 0, len(r.definitions))
+	for _, definition := range r.definitions {
+		found := *definition
+		definitions = append(definitions, &found)
+	}
+	slices.SortFunc(definitions, func(a, b *entity.AttributeDefinition) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return definitions, nil
+}
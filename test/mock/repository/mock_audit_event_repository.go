@@ -0,0 +1,81 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MockAuditEventRepository struct {
+	events []*entity.AuditEvent
+}
+
+func NewMockAuditEventRepository() *MockAuditEventRepository {
+	return &MockAuditEventRepository{}
+}
+
+func (r *MockAuditEventRepository) Create(ctx context.Context, event *entity.AuditEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *MockAuditEventRepository) FindAll(ctx context.Context, filter *repositories.AuditEventFilter, limit, offset int) ([]*entity.AuditEvent, int64, error) {
+	var events []*entity.AuditEvent
+	for _, event := range r.events {
+		if matchesAuditFilter(event, filter) {
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+
+	total := int64(len(events))
+	start := min(offset, len(events))
+	end := min(offset+limit, len(events))
+
+	return events[start:end], total, nil
+}
+
+func (r *MockAuditEventRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	var kept []*entity.AuditEvent
+	for _, event := range r.events {
+		if !event.CreatedAt.Before(before) {
+			kept = append(kept, event)
+		}
+	}
+
+	deleted := int64(len(r.events) - len(kept))
+	r.events = kept
+	return deleted, nil
+}
+
+func matchesAuditFilter(event *entity.AuditEvent, filter *repositories.AuditEventFilter) bool {
+	if filter.ActorID != nil && (event.ActorID == nil || *event.ActorID != *filter.ActorID) {
+		return false
+	}
+	if filter.TargetID != nil && (event.TargetID == nil || *event.TargetID != *filter.TargetID) {
+		return false
+	}
+	if filter.EventType != "" && event.EventType != filter.EventType {
+		return false
+	}
+	if filter.Outcome != "" && event.Outcome != filter.Outcome {
+		return false
+	}
+	if filter.From != nil && event.CreatedAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !event.CreatedAt.Before(*filter.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MockGroupRepository struct {
+	groups   map[uuid.UUID]*entity.Group
+	members  []*entity.GroupMember
+	userRepo *MockUserRepository
+}
+
+// NewMockGroupRepository looks members up in userRepo, like the real
+// repository joins the users table.
+func NewMockGroupRepository(userRepo *MockUserRepository) *MockGroupRepository {
+	return &MockGroupRepository{
+		groups:   make(map[uuid.UUID]*entity.Group),
+		userRepo: userRepo,
+	}
+}
+
+func (r *MockGroupRepository) Create(ctx context.Context, group *entity.Group) (*entity.Group, error) {
+	if group.ID == uuid.Nil {
+		group.ID = uuid.New()
+	}
+	group.CreatedAt = time.Now()
+	group.UpdatedAt = group.CreatedAt
+
+	stored := *group
+	r.groups[group.ID] = &stored
+	return group, nil
+}
+
+func (r *MockGroupRepository) Update(ctx context.Context, group *entity.Group) (*entity.Group, error) {
+	if _, exists := r.groups[group.ID]; !exists {
+		return nil, errors.ErrGroupNotFound
+	}
+	group.UpdatedAt = time.Now()
+
+	stored := *group
+	r.groups[group.ID] = &stored
+	return group, nil
+}
+
+func (r *MockGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, exists := r.groups[id]; !exists {
+		return errors.ErrGroupNotFound
+	}
+
+	r.members = slices.DeleteFunc(r.members, func(member *entity.GroupMember) bool {
+		return member.GroupID == id
+	})
+	delete(r.groups, id)
+	return nil
+}
+
+func (r *MockGroupRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Group, error) {
+	group, exists := r.groups[id]
+	if !exists {
+		return nil, errors.ErrGroupNotFound
+	}
+	found := *group
+	return &found, nil
+}
+
+func (r *MockGroupRepository) FindByName(ctx context.Context, name string) (*entity.Group, error) {
+	for _, group := range r.groups {
+		if group.Name == name {
+			found := *group
+			return &found, nil
+		}
+	}
+	return nil, errors.ErrGroupNotFound
+}
+
+func (r *MockGroupRepository) FindAll(ctx context.Context) ([]*entity.Group, error) {
+	groups := make([]*entity.Group, 0, len(r.groups))
+	for _, group := range r.groups {
+		found := *group
+		groups = append(groups, &found)
+	}
+	sortGroups(groups)
+	return groups, nil
+}
+
+func (r *MockGroupRepository) FindDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	var descendants []uuid.UUID
+	pending := []uuid.UUID{id}
+	for len(pending) > 0 {
+		parentID := pending[0]
+		pending = pending[1:]
+		for _, group := range r.groups {
+			if group.ParentID != nil && *group.ParentID == parentID && !slices.Contains(descendants, group.ID) {
+				descendants = append(descendants, group.ID)
+				pending = append(pending, group.ID)
+			}
+		}
+	}
+	return descendants, nil
+}
+
+func (r *MockGroupRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Group, error) {
+	seen := make(map[uuid.UUID]bool)
+	var groups []*entity.Group
+	for _, member := range r.members {
+		if member.UserID != userID {
+			continue
+		}
+		// Walk up to the top level group.
+		group := r.groups[member.GroupID]
+		for group != nil && !seen[group.ID] {
+			seen[group.ID] = true
+			found := *group
+			groups = append(groups, &found)
+			if group.ParentID == nil {
+				break
+			}
+			group = r.groups[*group.ParentID]
+		}
+	}
+	sortGroups(groups)
+	return groups, nil
+}
+
+func (r *MockGroupRepository) AddMember(ctx context.Context, member *entity.GroupMember) error {
+	if found, _ := r.FindMember(ctx, member.GroupID, member.UserID); found != nil {
+		return errors.ErrGroupMemberExists
+	}
+	member.CreatedAt = time.Now()
+
+	stored := *member
+	r.members = append(r.members, &stored)
+	return nil
+}
+
+func (r *MockGroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	index := slices.IndexFunc(r.members, func(member *entity.GroupMember) bool {
+		return member.GroupID == groupID && member.UserID == userID
+	})
+	if index < 0 {
+		return errors.ErrGroupMemberNotFound
+	}
+	r.members = slices.Delete(r.members, index, index+1)
+	return nil
+}
+
+func (r *MockGroupRepository) FindMember(ctx context.Context, groupID, userID uuid.UUID) (*entity.GroupMember, error) {
+	for _, member := range r.members {
+		if member.GroupID == groupID && member.UserID == userID {
+			found := *member
+			return &found, nil
+		}
+	}
+	return nil, errors.ErrGroupMemberNotFound
+}
+
+func (r *MockGroupRepository) FindMembers(ctx context.Context, groupID uuid.UUID, nested bool, limit, offset int) ([]*entity.User, int64, error) {
+	groupIDs := []uuid.UUID{groupID}
+	if nested {
+		descendants, _ := r.FindDescendantIDs(ctx, groupID)
+		groupIDs = append(groupIDs, descendants...)
+	}
+
+	var users []*entity.User
+	for _, member := range r.members {
+		user, exists := r.userRepo.users[member.UserID]
+		if !exists || user.IsDeleted || !slices.Contains(groupIDs, member.GroupID) || slices.Contains(users, user) {
+			continue
+		}
+		users = append(users, user)
+	}
+	slices.SortFunc(users, func(a, b *entity.User) int {
+		return strings.Compare(a.Email, b.Email)
+	})
+
+	total := int64(len(users))
+	if offset >= len(users) {
+		return []*entity.User{}, total, nil
+	}
+	return users[offset:min(offset+limit, len(users))], total, nil
+}
+
+func sortGroups(groups []*entity.Group) {
+	slices.SortFunc(groups, func(a, b *entity.Group) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
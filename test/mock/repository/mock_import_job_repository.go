@@ -0,0 +1,96 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MockImportJobRepository stores copies behind a lock because imports are
+// processed on a background goroutine while tests poll for progress.
+type MockImportJobRepository struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]entity.ImportJob
+	rows map[uuid.UUID][]entity.ImportJobRow
+}
+
+func NewMockImportJobRepository() *MockImportJobRepository {
+	return &MockImportJobRepository{
+		jobs: map[uuid.UUID]entity.ImportJob{},
+		rows: map[uuid.UUID][]entity.ImportJobRow{},
+	}
+}
+
+func (r *MockImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = job.CreatedAt
+	r.jobs[job.ID] = *job
+	return job, nil
+}
+
+func (r *MockImportJobRepository) Update(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.jobs[job.ID]; !exists {
+		return nil, errors.ErrImportJobNotFound
+	}
+	job.UpdatedAt = time.Now()
+	r.jobs[job.ID] = *job
+	return job, nil
+}
+
+func (r *MockImportJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, exists := r.jobs[id]
+	if !exists {
+		return nil, errors.ErrImportJobNotFound
+	}
+	return &job, nil
+}
+
+func (r *MockImportJobRepository) CreateRows(ctx context.Context, rows []*entity.ImportJobRow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, row := range rows {
+		if row.ID == uuid.Nil {
+			row.ID = uuid.New()
+		}
+		r.rows[row.ImportJobID] = append(r.rows[row.ImportJobID], *row)
+	}
+	return nil
+}
+
+func (r *MockImportJobRepository) FindRows(ctx context.Context, jobID uuid.UUID, limit, offset int) ([]*entity.ImportJobRow, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows := append([]entity.ImportJobRow(nil), r.rows[jobID]...)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Row < rows[j].Row })
+
+	total := int64(len(rows))
+	if offset > len(rows) {
+		offset = len(rows)
+	}
+	end := min(offset+limit, len(rows))
+
+	result := make([]*entity.ImportJobRow, 0, end-offset)
+	for i := offset; i < end; i++ {
+		result = append(result, &rows[i])
+	}
+	return result, total, nil
+}
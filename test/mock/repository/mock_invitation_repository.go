@@ -0,0 +1,64 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MockInvitationRepository struct {
+	invitations map[uuid.UUID]*entity.Invitation
+}
+
+func NewMockInvitationRepository() *MockInvitationRepository {
+	return &MockInvitationRepository{
+		invitations: map[uuid.UUID]*entity.Invitation{},
+	}
+}
+
+func (r *MockInvitationRepository) Create(ctx context.Context, invitation *entity.Invitation) (*entity.Invitation, error) {
+	if invitation.ID == uuid.Nil {
+		invitation.ID = uuid.New()
+	}
+	r.invitations[invitation.ID] = invitation
+	return invitation, nil
+}
+
+func (r *MockInvitationRepository) Update(ctx context.Context, invitation *entity.Invitation) (*entity.Invitation, error) {
+	if _, exists := r.invitations[invitation.ID]; !exists {
+		return nil, errors.ErrInvitationNotFound
+	}
+	r.invitations[invitation.ID] = invitation
+	return invitation, nil
+}
+
+func (r *MockInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	for _, invitation := range r.invitations {
+		if invitation.TokenHash == tokenHash {
+			return invitation, nil
+		}
+	}
+	return nil, errors.ErrInvitationNotFound
+}
+
+func (r *MockInvitationRepository) FindPendingByUserID(ctx context.Context, userID uuid.UUID) (*entity.Invitation, error) {
+	for _, invitation := range r.invitations {
+		if invitation.UserID == userID && invitation.IsPending() {
+			return invitation, nil
+		}
+	}
+	return nil, errors.ErrInvitationNotFound
+}
+
+func (r *MockInvitationRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error {
+	now := time.Now()
+	for _, invitation := range r.invitations {
+		if invitation.UserID == userID && invitation.AcceptedAt == nil && invitation.RevokedAt == nil {
+			invitation.RevokedAt = &now
+		}
+	}
+	return nil
+}
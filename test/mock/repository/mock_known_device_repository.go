@@ -0,0 +1,50 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+type MockKnownDeviceRepository struct {
+	devices map[uuid.UUID]*entity.KnownDevice
+}
+
+func NewMockKnownDeviceRepository() *MockKnownDeviceRepository {
+	return &MockKnownDeviceRepository{
+		devices: map[uuid.UUID]*entity.KnownDevice{},
+	}
+}
+
+func (r *MockKnownDeviceRepository) Create(ctx context.Context, device *entity.KnownDevice) (*entity.KnownDevice, error) {
+	if device.ID == uuid.Nil {
+		device.ID = uuid.New()
+	}
+	r.devices[device.ID] = device
+	return device, nil
+}
+
+func (r *MockKnownDeviceRepository) Update(ctx context.Context, device *entity.KnownDevice) (*entity.KnownDevice, error) {
+	r.devices[device.ID] = device
+	return device, nil
+}
+
+func (r *MockKnownDeviceRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.KnownDevice, error) {
+	var devices []*entity.KnownDevice
+	for _, device := range r.devices {
+		if device.UserID == userID {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+func (r *MockKnownDeviceRepository) DeleteAllByUserID(ctx context.Context, userID uuid.UUID) error {
+	for id, device := range r.devices {
+		if device.UserID == userID {
+			delete(r.devices, id)
+		}
+	}
+	return nil
+}
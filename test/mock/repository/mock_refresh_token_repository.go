@@ -0,0 +1,80 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MockRefreshTokenRepository struct {
+	tokens map[string]*entity.RefreshToken
+}
+
+func NewMockRefreshTokenRepository() *MockRefreshTokenRepository {
+	return &MockRefreshTokenRepository{
+		tokens: map[string]*entity.RefreshToken{},
+	}
+}
+
+func (r *MockRefreshTokenRepository) Save(ctx context.Context, token *entity.RefreshToken) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	r.tokens[token.Token] = token
+	return nil
+}
+
+func (r *MockRefreshTokenRepository) FindByToken(ctx context.Context, token string) (*entity.RefreshToken, error) {
+	if stored, exists := r.tokens[token]; exists && r.isValid(stored) {
+		return stored, nil
+	}
+	return nil, errors.ErrTokenNotFound
+}
+
+func (r *MockRefreshTokenRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.RefreshToken, error) {
+	var tokens []*entity.RefreshToken
+	for _, token := range r.tokens {
+		if token.UserID == userID && r.isValid(token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *MockRefreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error {
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			token.IsRevoked = true
+		}
+	}
+	return nil
+}
+
+func (r *MockRefreshTokenRepository) RevokeByToken(ctx context.Context, token string) (int64, error) {
+	if stored, exists := r.tokens[token]; exists && !stored.IsRevoked {
+		stored.IsRevoked = true
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (r *MockRefreshTokenRepository) DeleteExpired(ctx context.Context) error {
+	for key, token := range r.tokens {
+		if token.ExpiresAt.Before(time.Now()) {
+			delete(r.tokens, key)
+		}
+	}
+	return nil
+}
+
+func (r *MockRefreshTokenRepository) IsTokenValid(ctx context.Context, token string) bool {
+	stored, exists := r.tokens[token]
+	return exists && r.isValid(stored)
+}
+
+func (r *MockRefreshTokenRepository) isValid(token *entity.RefreshToken) bool {
+	return !token.IsRevoked && token.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,55 @@
+package mock_repository
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MockUserPreferencesRepository struct {
+	preferences map[uuid.UUID]*entity.UserPreferences
+	userRepo    *MockUserRepository
+}
+
+// NewMockUserPreferencesRepository resolves emails through the users of
+// userRepo, like the real repository joins on users.
+func NewMockUserPreferencesRepository(userRepo *MockUserRepository) *MockUserPreferencesRepository {
+	return &MockUserPreferencesRepository{
+		preferences: make(map[uuid.UUID]*entity.UserPreferences),
+		userRepo:    userRepo,
+	}
+}
+
+func (r *MockUserPreferencesRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.UserPreferences, error) {
+	preferences, ok := r.preferences[userID]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	stored := *preferences
+	return &stored, nil
+}
+
+func (r *MockUserPreferencesRepository) FindByEmail(ctx context.Context, email string) (*entity.UserPreferences, error) {
+	user, err := r.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return r.FindByUserID(ctx, user.ID)
+}
+
+func (r *MockUserPreferencesRepository) Save(ctx context.Context, preferences *entity.UserPreferences) (*entity.UserPreferences, error) {
+	now := time.Now()
+	if existing, ok := r.preferences[preferences.UserID]; ok {
+		preferences.CreatedAt = existing.CreatedAt
+	} else {
+		preferences.CreatedAt = now
+	}
+	preferences.UpdatedAt = now
+
+	stored := *preferences
+	r.preferences[preferences.UserID] = &stored
+	return preferences, nil
+}
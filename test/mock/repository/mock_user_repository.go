@@ -3,7 +3,11 @@ package mock_repository
 import (
 	"context"
 	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
 	"go-gin-hexagonal/pkg/errors"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,7 +24,8 @@ const (
 )
 
 type MockUserRepository struct {
-	users map[uuid.UUID]*entity.User
+	users   map[uuid.UUID]*entity.User
+	aliases map[string]*entity.UsernameAlias
 }
 
 func NewMockUserRepository() *MockUserRepository {
@@ -33,30 +38,39 @@ func NewMockUserRepository() *MockUserRepository {
 				Username: testUsername,
 				Password: "hashedpassword",
 				Name:     testName,
-				IsActive: true,
+				Status:   entity.UserStatusActive,
 				AuditInfo: entity.AuditInfo{
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 					DeletedAt: nil,
 					IsDeleted: false,
+					Version:   1,
 				},
 			},
 		},
+		aliases: map[string]*entity.UsernameAlias{},
 	}
 }
 
 func (r *MockUserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+
 	_, exists := r.users[user.ID]
 	if exists {
 		return nil, errors.ErrUserAlreadyExists
 	}
+	if user.Version == 0 {
+		user.Version = 1
+	}
 
 	r.users[user.ID] = user
 	return user, nil
 }
 
 func (r *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	if user, exists := r.users[id]; exists {
+	if user, exists := r.users[id]; exists && !user.IsDeleted {
 		return user, nil
 	}
 	return nil, errors.ErrUserNotFound
@@ -64,7 +78,7 @@ func (r *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entit
 
 func (r *MockUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	for _, user := range r.users {
-		if user.Email == email {
+		if user.Email == email && !user.IsDeleted {
 			return user, nil
 		}
 	}
@@ -73,7 +87,12 @@ func (r *MockUserRepository) FindByEmail(ctx context.Context, email string) (*en
 
 func (r *MockUserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
 	for _, user := range r.users {
-		if user.Username == username {
+		if user.Username == username && !user.IsDeleted {
+			return user, nil
+		}
+	}
+	if alias, ok := r.aliases[username]; ok && alias.ExpiresAt.After(time.Now()) {
+		if user, ok := r.users[alias.UserID]; ok && !user.IsDeleted {
 			return user, nil
 		}
 	}
@@ -81,29 +100,132 @@ func (r *MockUserRepository) FindByUsername(ctx context.Context, username string
 }
 
 func (r *MockUserRepository) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
-	_, exists := r.users[user.ID]
+	stored, exists := r.users[user.ID]
 	if !exists {
 		return nil, errors.ErrUserNotFound
 	}
+	if stored.Version != user.Version {
+		return nil, errors.ErrVersionConflict
+	}
 
 	user.UpdatedAt = time.Now()
+	user.Version++
 	r.users[user.ID] = user
 	return user, nil
 }
 
 func (r *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if _, exists := r.users[id]; !exists {
+	user, exists := r.users[id]
+	if !exists || user.IsDeleted {
 		return errors.ErrUserNotFound
 	}
-	delete(r.users, id)
+
+	now := time.Now()
+	user.DeletedAt = &now
+	user.IsDeleted = true
 	return nil
 }
 
-func (r *MockUserRepository) FindAll(ctx context.Context, limit, offset int, search string) ([]*entity.User, int64, error) {
+func (r *MockUserRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	if user, exists := r.users[id]; exists && user.IsDeleted {
+		return user, nil
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+func (r *MockUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	user, exists := r.users[id]
+	if !exists || !user.IsDeleted {
+		return errors.ErrUserNotFound
+	}
+
+	user.DeletedAt = nil
+	user.IsDeleted = false
+	return nil
+}
+
+func (r *MockUserRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]*entity.User, error) {
+	var users []*entity.User
+	for id, user := range r.users {
+		if user.IsDeleted && user.DeletedAt.Before(deletedBefore) {
+			users = append(users, user)
+			delete(r.users, id)
+		}
+	}
+	return users, nil
+}
+
+func (r *MockUserRepository) FindAll(ctx context.Context, filter *repositories.UserFilter, sort *repositories.SortOption, limit, offset int) ([]*entity.User, int64, error) {
+	less := func(a, b *entity.User) bool { return a.CreatedAt.After(b.CreatedAt) }
+	if sort != nil && sort.Field != "" {
+		var key func(user *entity.User) string
+		switch sort.Field {
+		case "email":
+			key = func(user *entity.User) string { return user.Email }
+		case "username":
+			key = func(user *entity.User) string { return user.Username }
+		case "name":
+			key = func(user *entity.User) string { return user.Name }
+		case "status":
+			key = func(user *entity.User) string { return user.Status }
+		case "created_at":
+			key = func(user *entity.User) string { return user.CreatedAt.Format(time.RFC3339Nano) }
+		case "updated_at":
+			key = func(user *entity.User) string { return user.UpdatedAt.Format(time.RFC3339Nano) }
+		default:
+			return nil, 0, errors.ErrInvalidSortField
+		}
+		less = func(a, b *entity.User) bool {
+			if sort.Desc {
+				return key(a) > key(b)
+			}
+			return key(a) < key(b)
+		}
+	}
+
 	var users []*entity.User
 	for _, user := range r.users {
+		if user.IsDeleted && !filter.IncludeDeleted {
+			continue
+		}
+		if filter.Search != "" && !matchesSearch(user, filter.Search) {
+			continue
+		}
+		if filter.Email != "" && user.Email != filter.Email {
+			continue
+		}
+		if filter.Status != "" && user.Status != filter.Status {
+			continue
+		}
+		if filter.IsActive != nil && user.IsActive() != *filter.IsActive {
+			continue
+		}
+		if filter.CreatedFrom != nil && user.CreatedAt.Before(*filter.CreatedFrom) {
+			continue
+		}
+		if filter.CreatedTo != nil && !user.CreatedAt.Before(*filter.CreatedTo) {
+			continue
+		}
+		if filter.UpdatedFrom != nil && user.UpdatedAt.Before(*filter.UpdatedFrom) {
+			continue
+		}
+		if filter.UpdatedTo != nil && !user.UpdatedAt.Before(*filter.UpdatedTo) {
+			continue
+		}
+		if !containsAttributes(user.Attributes, filter.Attributes) {
+			continue
+		}
 		users = append(users, user)
 	}
+	slices.SortFunc(users, func(a, b *entity.User) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	})
 
 	total := int64(len(users))
 	start := min(offset, len(users))
@@ -112,6 +234,32 @@ func (r *MockUserRepository) FindAll(ctx context.Context, limit, offset int, sea
 	return users[start:end], total, nil
 }
 
+// FindPage uses the offset as cursor. Callers treat cursors as opaque, so this
+// stands in for the keyset cursors of the real repository.
+func (r *MockUserRepository) FindPage(ctx context.Context, filter *repositories.UserFilter, sort *repositories.SortOption, cursor string, limit int) (*repositories.CursorPage[entity.User], error) {
+	offset := 0
+	if cursor != "" {
+		var err error
+		if offset, err = strconv.Atoi(cursor); err != nil || offset < 0 {
+			return nil, errors.ErrInvalidCursor
+		}
+	}
+
+	users, total, err := r.FindAll(ctx, filter, sort, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &repositories.CursorPage[entity.User]{Items: users}
+	if int64(offset+limit) < total {
+		page.NextCursor = strconv.Itoa(offset + limit)
+	}
+	if offset > 0 {
+		page.PrevCursor = strconv.Itoa(max(offset-limit, 0))
+	}
+	return page, nil
+}
+
 func (r *MockUserRepository) ExistsByEmail(ctx context.Context, email string) bool {
 	for _, user := range r.users {
 		if user.Email == email {
@@ -127,5 +275,123 @@ func (r *MockUserRepository) ExistsByUsername(ctx context.Context, username stri
 			return true
 		}
 	}
+	alias, ok := r.aliases[username]
+	return ok && alias.ExpiresAt.After(time.Now())
+}
+
+func (r *MockUserRepository) ExistsByAttribute(ctx context.Context, key string, value any, excludeID uuid.UUID) (bool, error) {
+	for _, user := range r.users {
+		if user.ID != excludeID && !user.IsDeleted && containsAttributes(user.Attributes, map[string]any{key: value}) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *MockUserRepository) HasDuplicateAttribute(ctx context.Context, key string) (bool, error) {
+	seen := make(map[any]bool)
+	for _, user := range r.users {
+		value, ok := user.Attributes[key]
+		if !ok || user.IsDeleted {
+			continue
+		}
+		if seen[value] {
+			return true, nil
+		}
+		seen[value] = true
+	}
+	return false, nil
+}
+
+// containsAttributes mirrors jsonb containment for the scalar values
+// attributes hold.
+func containsAttributes(attributes entity.UserAttributes, want map[string]any) bool {
+	for key, value := range want {
+		if got, ok := attributes[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *MockUserRepository) FindPasswordExpiring(ctx context.Context, changedBefore time.Time) ([]*entity.User, error) {
+	var users []*entity.User
+	for _, user := range r.users {
+		changedAt := user.CreatedAt
+		if user.PasswordChangedAt != nil {
+			changedAt = *user.PasswordChangedAt
+		}
+
+		reminded := user.PasswordReminderSentAt != nil && !user.PasswordReminderSentAt.Before(changedAt)
+		if !user.IsDeleted && user.IsActive() && user.Password != "" && changedAt.Before(changedBefore) && !reminded {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *MockUserRepository) FindExpiredSuspensions(ctx context.Context, now time.Time) ([]*entity.User, error) {
+	var users []*entity.User
+	for _, user := range r.users {
+		if !user.IsDeleted && user.SuspensionExpired(now) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *MockUserRepository) FindByApprovalStatus(ctx context.Context, status string, limit, offset int) ([]*entity.User, int64, error) {
+	var users []*entity.User
+	for _, user := range r.users {
+		if !user.IsDeleted && user.ApprovalStatus == status {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b *entity.User) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	total := int64(len(users))
+	start := min(offset, len(users))
+	end := min(offset+limit, len(users))
+
+	return users[start:end], total, nil
+}
+
+// matchesSearch approximates the repository search with a case-insensitive
+// substring match; typo tolerance needs the database.
+func (r *MockUserRepository) SaveUsernameAlias(ctx context.Context, alias *entity.UsernameAlias) error {
+	alias.CreatedAt = time.Now()
+	stored := *alias
+	r.aliases[alias.Username] = &stored
+	return nil
+}
+
+func (r *MockUserRepository) DeleteUsernameAlias(ctx context.Context, username string) error {
+	delete(r.aliases, username)
+	return nil
+}
+
+func (r *MockUserRepository) DeleteExpiredUsernameAliases(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	for username, alias := range r.aliases {
+		if !alias.ExpiresAt.After(now) {
+			delete(r.aliases, username)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func matchesSearch(user *entity.User, search string) bool {
+	search = strings.ToLower(search)
+	for _, field := range []string{user.Name, user.Username, user.Email} {
+		if strings.Contains(strings.ToLower(field), search) {
+			return true
+		}
+	}
 	return false
 }
@@ -0,0 +1,167 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	gormadapter "go-gin-hexagonal/internal/adapter/database/gorm"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
+	"go-gin-hexagonal/pkg/errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// pageRow is a minimal entity for exercising keyset pagination.
+type pageRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// stubDatabase is a database/sql connector that answers every query with the
+// next queued result set and records what was asked, so the SQL built by the
+// repositories runs without Postgres.
+type stubDatabase struct {
+	results [][]pageRow
+	queries []string
+	args    [][]any
+}
+
+func (d *stubDatabase) Connect(ctx context.Context) (driver.Conn, error) { return &stubConn{d}, nil }
+func (d *stubDatabase) Driver() driver.Driver                            { return stubDriver{} }
+
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) {
+	return nil, fmt.Errorf("stub database cannot open %q", name)
+}
+
+type stubConn struct{ db *stubDatabase }
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("stub database cannot prepare %q", query)
+}
+func (c *stubConn) Close() error { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("stub database has no transactions")
+}
+
+func (c *stubConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.db.queries = append(c.db.queries, query)
+	c.db.args = append(c.db.args, values)
+
+	var rows []pageRow
+	if len(c.db.results) > 0 {
+		rows, c.db.results = c.db.results[0], c.db.results[1:]
+	}
+	return &stubRows{rows: rows}, nil
+}
+
+type stubRows struct {
+	rows []pageRow
+	next int
+}
+
+func (r *stubRows) Columns() []string { return []string{"id", "name"} }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	dest[0] = r.rows[r.next].ID.String()
+	dest[1] = r.rows[r.next].Name
+	r.next++
+	return nil
+}
+
+type PaginationTestSuite struct {
+	suite.Suite
+	db   *stubDatabase
+	repo repositories.BaseRepository[pageRow]
+	rows []pageRow
+	ctx  context.Context
+}
+
+func (suite *PaginationTestSuite) SetupTest() {
+	suite.db = &stubDatabase{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(suite.db)}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	suite.Require().NoError(err)
+
+	suite.repo = gormadapter.NewBaseRepository[pageRow](db)
+	suite.rows = []pageRow{{uuid.New(), "ada"}, {uuid.New(), "bob"}, {uuid.New(), "cyd"}}
+	suite.ctx = context.Background()
+}
+
+func TestPaginationTestSuite(t *testing.T) {
+	suite.Run(t, new(PaginationTestSuite))
+}
+
+func (suite *PaginationTestSuite) findPage(cursor string) (*repositories.CursorPage[pageRow], error) {
+	return suite.repo.FindPage(suite.ctx, &repositories.CursorQuery{Cursor: cursor, Limit: 2, Column: "name"}, nil)
+}
+
+func (suite *PaginationTestSuite) TestFindPage() {
+	// One row past the limit tells there is a next page; it is not returned.
+	suite.db.results = [][]pageRow{suite.rows}
+	page, err := suite.findPage("")
+	suite.Require().NoError(err)
+	suite.Equal(suite.rows[:2], derefRows(page.Items))
+	suite.Empty(page.PrevCursor)
+	suite.Require().NotEmpty(page.NextCursor)
+	suite.Equal([]any{int64(3)}, suite.db.args[0])
+
+	// The cursor carries the last row's sort key and id into the next query.
+	suite.db.results = [][]pageRow{suite.rows[2:]}
+	page, err = suite.findPage(page.NextCursor)
+	suite.Require().NoError(err)
+	suite.Equal(suite.rows[2:], derefRows(page.Items))
+	suite.Equal([]any{"bob", suite.rows[1].ID.String(), int64(3)}, suite.db.args[1])
+
+	// Fewer rows than the probe means this is the last page.
+	suite.Empty(page.NextCursor)
+	suite.NotEmpty(page.PrevCursor)
+
+	// So does a page that exactly fills the limit.
+	suite.db.results = [][]pageRow{suite.rows[:2]}
+	page, err = suite.findPage("")
+	suite.Require().NoError(err)
+	suite.Len(page.Items, 2)
+	suite.Empty(page.NextCursor)
+}
+
+func (suite *PaginationTestSuite) TestFindPageRejectsInvalidCursor() {
+	encode := func(json string) string { return base64.RawURLEncoding.EncodeToString([]byte(json)) }
+
+	for name, cursor := range map[string]string{
+		"not base64":     "%%%",
+		"not json":       encode("ada"),
+		"other column":   encode(`{"c":"email","v":"ada","id":"` + suite.rows[0].ID.String() + `"}`),
+		"missing column": encode(`{"v":"ada","id":"` + suite.rows[0].ID.String() + `"}`),
+	} {
+		_, err := suite.findPage(cursor)
+		suite.ErrorIs(err, errors.ErrInvalidCursor, name)
+	}
+	suite.Empty(suite.db.queries)
+}
+
+func derefRows(rows []*pageRow) []pageRow {
+	values := make([]pageRow, len(rows))
+	for i, row := range rows {
+		values[i] = *row
+	}
+	return values
+}
@@ -0,0 +1,254 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"go-gin-hexagonal/internal/adapter/http/handlers"
+	"go-gin-hexagonal/internal/adapter/http/patch"
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+	mock_external "go-gin-hexagonal/test/mock/external"
+	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PatchTestSuite struct {
+	suite.Suite
+	mockUserRepo *mock_repository.MockUserRepository
+	userService  services.UserService
+	router       *gin.Engine
+	ctx          context.Context
+}
+
+func (suite *PatchTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	suite.mockUserRepo = mock_repository.NewMockUserRepository()
+	attributeRepo := mock_repository.NewMockAttributeDefinitionRepository(suite.mockUserRepo)
+	attributeService := service.NewAttributeService(attributeRepo, suite.mockUserRepo)
+
+	mailer := mock_external.NewMockEmailService()
+	mailer.On("SendInvitationEmail", mock.Anything, mock.Anything).Return(nil)
+
+	suite.userService = newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:      suite.mockUserRepo,
+		Mailer:        mailer,
+		AttributeRepo: attributeRepo,
+	})
+	suite.ctx = context.Background()
+
+	for _, req := range []*services.CreateAttributeDefinitionRequest{
+		{Key: "department", Label: "Department", Type: entity.AttributeTypeString},
+		{Key: "level", Label: "Level", Type: entity.AttributeTypeNumber},
+	} {
+		_, err := attributeService.CreateDefinition(suite.ctx, req)
+		suite.Require().NoError(err)
+	}
+
+	userHandler := handlers.NewUserHandler(suite.userService)
+	suite.router = gin.New()
+	adminID := uuid.New()
+	suite.router.GET("/users/:id", userHandler.GetUserByID)
+	suite.router.PUT("/users/:id", func(c *gin.Context) { c.Set("user_id", adminID) }, userHandler.UpdateUser)
+	suite.router.PATCH("/users/:id", func(c *gin.Context) { c.Set("user_id", adminID) }, userHandler.PatchUser)
+}
+
+func TestPatchTestSuite(t *testing.T) {
+	suite.Run(t, new(PatchTestSuite))
+}
+
+func (suite *PatchTestSuite) apply(mediaType, document, patchDocument string) (map[string]any, error) {
+	patched, err := patch.Apply(mediaType, []byte(document), []byte(patchDocument))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	suite.Require().NoError(json.Unmarshal(patched, &result))
+	return result, nil
+}
+
+func (suite *PatchTestSuite) TestMergePatch() {
+	document := `{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"], "content": "This will be unchanged"}`
+
+	result, err := suite.apply(patch.MergePatchType, document, `{"title": "Hello!", "phoneNumber": "+01-123-456-7890", "author": {"familyName": null}, "tags": ["example"]}`)
+	suite.Require().NoError(err)
+	suite.Equal(map[string]any{
+		"title":       "Hello!",
+		"author":      map[string]any{"givenName": "John"},
+		"tags":        []any{"example"},
+		"content":     "This will be unchanged",
+		"phoneNumber": "+01-123-456-7890",
+	}, result)
+
+	_, err = suite.apply(patch.MergePatchType, document, `{"title":`)
+	suite.Equal(errors.ErrInvalidPatch, err)
+}
+
+func (suite *PatchTestSuite) TestJSONPatch() {
+	document := `{"foo": "bar", "list": [1, 2], "a/b": {"~c": true}}`
+
+	result, err := suite.apply(patch.JSONPatchType, document, `[
+		{"op": "test", "path": "/foo", "value": "bar"},
+		{"op": "add", "path": "/list/1", "value": 5},
+		{"op": "add", "path": "/list/-", "value": 9},
+		{"op": "remove", "path": "/list/0"},
+		{"op": "replace", "path": "/a~1b/~0c", "value": false},
+		{"op": "copy", "from": "/list", "path": "/copied"},
+		{"op": "move", "from": "/foo", "path": "/baz"}
+	]`)
+	suite.Require().NoError(err)
+	suite.Equal(map[string]any{
+		"baz":    "bar",
+		"list":   []any{5.0, 2.0, 9.0},
+		"copied": []any{5.0, 2.0, 9.0},
+		"a/b":    map[string]any{"~c": false},
+	}, result)
+
+	cases := []struct {
+		patch string
+		err   error
+	}{
+		{`{"op": "add", "path": "/foo", "value": 1}`, errors.ErrInvalidPatch},
+		{`[{"op": "add", "path": "/foo"}]`, errors.ErrInvalidPatch},
+		{`[{"op": "merge", "path": "/foo", "value": 1}]`, errors.ErrInvalidPatch},
+		{`[{"op": "remove", "path": "foo"}]`, errors.ErrInvalidPatch},
+		{`[{"op": "remove", "path": "/~2"}]`, errors.ErrInvalidPatch},
+		{`[{"op": "move", "from": "/a~1b", "path": "/a~1b/inner"}]`, errors.ErrInvalidPatch},
+		{`[{"op": "remove", "path": "/missing"}]`, errors.ErrPatchConflict},
+		{`[{"op": "replace", "path": "/missing", "value": 1}]`, errors.ErrPatchConflict},
+		{`[{"op": "add", "path": "/missing/child", "value": 1}]`, errors.ErrPatchConflict},
+		{`[{"op": "add", "path": "/list/3", "value": 1}]`, errors.ErrPatchConflict},
+		{`[{"op": "remove", "path": "/list/01"}]`, errors.ErrPatchConflict},
+		{`[{"op": "test", "path": "/foo", "value": "baz"}]`, errors.ErrPatchTestFailed},
+	}
+	for _, tc := range cases {
+		_, err := suite.apply(patch.JSONPatchType, document, tc.patch)
+		suite.Equal(tc.err, err, tc.patch)
+	}
+
+	// A failing operation leaves nothing of the earlier ones behind.
+	_, err = suite.apply(patch.JSONPatchType, document, `[{"op": "remove", "path": "/foo"}, {"op": "remove", "path": "/foo"}]`)
+	suite.Equal(errors.ErrPatchConflict, err)
+}
+
+func (suite *PatchTestSuite) patchUser(userID uuid.UUID, mediaType, body, ifMatch string) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(http.MethodPatch, "/users/"+userID.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", mediaType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	recorder := httptest.NewRecorder()
+	suite.router.ServeHTTP(recorder, req)
+
+	var response map[string]any
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	data, _ := response["data"].(map[string]any)
+	return recorder, data
+}
+
+func (suite *PatchTestSuite) TestPatchUser() {
+	user, err := suite.userService.CreateUser(suite.ctx, &services.CreateUserRequest{
+		Email:      "patch@example.com",
+		Name:       "Patch User",
+		Attributes: map[string]any{"department": "Sales", "level": 2.0},
+	})
+	suite.Require().NoError(err)
+
+	recorder, data := suite.patchUser(user.ID, patch.MergePatchType, `{"name": "Merged Name", "attributes": {"level": null}}`, `"1"`)
+	suite.Require().Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	suite.Equal("Merged Name", data["name"])
+	suite.Equal(map[string]any{"department": "Sales"}, data["attributes"])
+	suite.Equal(`"2"`, recorder.Header().Get("ETag"))
+
+	recorder, data = suite.patchUser(user.ID, patch.JSONPatchType+"; charset=utf-8", `[
+		{"op": "test", "path": "/name", "value": "Merged Name"},
+		{"op": "replace", "path": "/username", "value": "patched_user"},
+		{"op": "add", "path": "/attributes/level", "value": 4}
+	]`, `"1", "2"`)
+	suite.Require().Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	suite.Equal("patched_user", data["username"])
+	suite.Equal(4.0, data["attributes"].(map[string]any)["level"])
+
+	// Patches that change nothing do not bump the version.
+	recorder, _ = suite.patchUser(user.ID, patch.MergePatchType, `{"name": "Merged Name"}`, "")
+	suite.Require().Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`"3"`, recorder.Header().Get("ETag"))
+
+	cases := []struct {
+		name      string
+		mediaType string
+		body      string
+		ifMatch   string
+		code      int
+	}{
+		{"plain JSON", "application/json", `{"name": "Plain Name"}`, "", http.StatusUnsupportedMediaType},
+		{"malformed patch", patch.JSONPatchType, `{"op": "remove"}`, "", http.StatusBadRequest},
+		{"failed test", patch.JSONPatchType, `[{"op": "test", "path": "/name", "value": "Other"}]`, "", http.StatusConflict},
+		{"stale version", patch.MergePatchType, `{"name": "Stale Name"}`, `"1"`, http.StatusPreconditionFailed},
+		{"stale versions", patch.MergePatchType, `{"name": "Stale Name"}`, `"1", "2"`, http.StatusPreconditionFailed},
+		{"weak version", patch.MergePatchType, `{"name": "Stale Name"}`, `W/"3"`, http.StatusPreconditionFailed},
+		{"cleared name", patch.MergePatchType, `{"name": null}`, "", http.StatusUnprocessableEntity},
+		{"short username", patch.JSONPatchType, `[{"op": "replace", "path": "/username", "value": "ab"}]`, "", http.StatusUnprocessableEntity},
+		{"unknown field", patch.MergePatchType, `{"email": "other@example.com"}`, "", http.StatusUnprocessableEntity},
+		{"wrong type", patch.MergePatchType, `{"name": 12}`, "", http.StatusUnprocessableEntity},
+		{"unknown attribute", patch.MergePatchType, `{"attributes": {"team": "A"}}`, "", http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		recorder, _ := suite.patchUser(user.ID, tc.mediaType, tc.body, tc.ifMatch)
+		suite.Equal(tc.code, recorder.Code, tc.name)
+	}
+
+	stored, err := suite.mockUserRepo.FindByID(suite.ctx, user.ID)
+	suite.Require().NoError(err)
+	suite.Equal("Merged Name", stored.Name)
+	suite.Equal(3, stored.Version)
+
+	recorder, _ = suite.patchUser(uuid.New(), patch.MergePatchType, `{"name": "Nobody"}`, "")
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *PatchTestSuite) TestUpdateUserMatchesETag() {
+	user, err := suite.userService.CreateUser(suite.ctx, &services.CreateUserRequest{
+		Email: "put@example.com",
+		Name:  "Put User",
+	})
+	suite.Require().NoError(err)
+
+	send := func(method, body string) (*httptest.ResponseRecorder, map[string]any) {
+		req := httptest.NewRequest(method, "/users/"+user.ID.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		recorder := httptest.NewRecorder()
+		suite.router.ServeHTTP(recorder, req)
+
+		var response map[string]any
+		suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		data, _ := response["data"].(map[string]any)
+		return recorder, data
+	}
+
+	// Responses carry the same version as their ETag, in the API's field names.
+	recorder, data := send(http.MethodPut, `{"name": "Put Name"}`)
+	suite.Require().Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	suite.Equal(`"2"`, recorder.Header().Get("ETag"))
+	suite.Equal(2.0, data["version"])
+	suite.NotContains(data, "Version")
+
+	recorder, data = send(http.MethodGet, "")
+	suite.Require().Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	suite.Equal(`"2"`, recorder.Header().Get("ETag"))
+	suite.Equal(2.0, data["version"])
+	suite.Equal("Put Name", data["name"])
+}
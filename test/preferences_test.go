@@ -0,0 +1,145 @@
+package test
+
+import (
+	"context"
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/errors"
+	mock_external "go-gin-hexagonal/test/mock/external"
+	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PreferencesTestSuite struct {
+	suite.Suite
+	mockUserRepo       *mock_repository.MockUserRepository
+	mockMailer         *mock_external.MockMailerManager
+	preferencesService services.PreferencesService
+	emailService       services.EmailService
+	user               *entity.User
+	ctx                context.Context
+}
+
+func (suite *PreferencesTestSuite) SetupTest() {
+	suite.mockUserRepo = mock_repository.NewMockUserRepository()
+	preferencesRepo := mock_repository.NewMockUserPreferencesRepository(suite.mockUserRepo)
+	suite.mockMailer = mock_external.NewMockMailerManager()
+
+	suite.preferencesService = service.NewPreferencesService(preferencesRepo, suite.mockUserRepo)
+	suite.emailService = service.NewEmailService(suite.mockMailer, preferencesRepo)
+	suite.ctx = context.Background()
+
+	user, err := suite.mockUserRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+	suite.user = user
+}
+
+func TestPreferencesTestSuite(t *testing.T) {
+	suite.Run(t, new(PreferencesTestSuite))
+}
+
+func (suite *PreferencesTestSuite) TestGetPreferencesDefaults() {
+	preferences, err := suite.preferencesService.GetPreferences(suite.ctx, suite.user.ID)
+	suite.Require().NoError(err)
+	suite.Equal(entity.DefaultLocale, preferences.Locale)
+	suite.Equal(entity.DefaultTimezone, preferences.Timezone)
+	suite.Equal(entity.DateFormatISO, preferences.DateFormat)
+	suite.Equal(map[string]bool{entity.NotificationAccount: true}, preferences.EmailNotifications)
+	suite.True(preferences.UpdatedAt.IsZero())
+
+	_, err = suite.preferencesService.GetPreferences(suite.ctx, uuid.New())
+	suite.Equal(errors.ErrUserNotFound, err)
+}
+
+func (suite *PreferencesTestSuite) TestUpdatePreferences() {
+	locale, timezone := "id", "Asia/Jakarta"
+	preferences, err := suite.preferencesService.UpdatePreferences(suite.ctx, suite.user.ID, &services.UpdatePreferencesRequest{
+		Locale:             &locale,
+		Timezone:           &timezone,
+		EmailNotifications: map[string]bool{entity.NotificationAccount: false},
+	})
+	suite.Require().NoError(err)
+	suite.Equal("id", preferences.Locale)
+	suite.Equal("Asia/Jakarta", preferences.Timezone)
+	suite.Equal(entity.DateFormatISO, preferences.DateFormat)
+	suite.False(preferences.EmailNotifications[entity.NotificationAccount])
+
+	// Later updates only touch the given fields and categories.
+	dateFormat := entity.DateFormatDMY
+	preferences, err = suite.preferencesService.UpdatePreferences(suite.ctx, suite.user.ID, &services.UpdatePreferencesRequest{
+		DateFormat: &dateFormat,
+	})
+	suite.Require().NoError(err)
+	suite.Equal("id", preferences.Locale)
+	suite.Equal(entity.DateFormatDMY, preferences.DateFormat)
+	suite.Equal(map[string]bool{entity.NotificationAccount: false}, preferences.EmailNotifications)
+	suite.False(preferences.UpdatedAt.IsZero())
+}
+
+func (suite *PreferencesTestSuite) TestUpdatePreferencesValidation() {
+	text := func(value string) *string { return &value }
+
+	cases := []struct {
+		req *services.UpdatePreferencesRequest
+		err error
+	}{
+		{&services.UpdatePreferencesRequest{Locale: text("fr")}, errors.ErrUnsupportedLocale},
+		{&services.UpdatePreferencesRequest{Timezone: text("Mars/Olympus")}, errors.ErrInvalidTimezone},
+		{&services.UpdatePreferencesRequest{Timezone: text("Local")}, errors.ErrInvalidTimezone},
+		{&services.UpdatePreferencesRequest{Timezone: text("")}, errors.ErrInvalidTimezone},
+		{&services.UpdatePreferencesRequest{DateFormat: text("DD.MM.YYYY")}, errors.ErrInvalidDateFormat},
+		{&services.UpdatePreferencesRequest{EmailNotifications: map[string]bool{"marketing": false}}, errors.ErrUnknownNotificationCategory},
+		{&services.UpdatePreferencesRequest{EmailNotifications: map[string]bool{"reminders": false}}, errors.ErrUnknownNotificationCategory},
+	}
+	for _, tc := range cases {
+		_, err := suite.preferencesService.UpdatePreferences(suite.ctx, suite.user.ID, tc.req)
+		suite.Equal(tc.err, err)
+	}
+
+	preferences, err := suite.preferencesService.GetPreferences(suite.ctx, suite.user.ID)
+	suite.Require().NoError(err)
+	suite.Equal(entity.DefaultLocale, preferences.Locale)
+}
+
+func (suite *PreferencesTestSuite) TestEmailServiceUsesLocale() {
+	locale := "id"
+	_, err := suite.preferencesService.UpdatePreferences(suite.ctx, suite.user.ID, &services.UpdatePreferencesRequest{Locale: &locale})
+	suite.Require().NoError(err)
+
+	data := &services.ResetPasswordData{ResetLink: "https://example.com/reset"}
+	suite.mockMailer.On("LoadEmailTemplate", "reset_password", "id", data).Return("body", nil)
+	suite.mockMailer.On("SendEmail", testEmail, "Atur Ulang Kata Sandi Akun Go Gin Hexagonal Application", "body").Return(nil)
+	suite.Require().NoError(suite.emailService.SendRequestResetPassword(testEmail, data))
+
+	// Recipients without an account get the default locale.
+	verify := &services.VerifyEmailData{VerificationURL: "https://example.com/verify"}
+	suite.mockMailer.On("LoadEmailTemplate", "verify_email", entity.DefaultLocale, verify).Return("body", nil)
+	suite.mockMailer.On("SendEmail", "new@example.com", "Verify your email for Go Gin Hexagonal Application", "body").Return(nil)
+	suite.Require().NoError(suite.emailService.SendVerifyEmail("new@example.com", verify))
+
+	suite.mockMailer.AssertExpectations(suite.T())
+}
+
+func (suite *PreferencesTestSuite) TestEmailServiceRespectsOptOuts() {
+	_, err := suite.preferencesService.UpdatePreferences(suite.ctx, suite.user.ID, &services.UpdatePreferencesRequest{
+		EmailNotifications: map[string]bool{entity.NotificationAccount: false},
+	})
+	suite.Require().NoError(err)
+
+	suite.NoError(suite.emailService.SendAccountSuspendedEmail(testEmail, &services.AccountSuspendedData{Name: "Test User"}))
+	suite.NoError(suite.emailService.SendRegistrationApprovedEmail(testEmail, &services.RegistrationApprovedData{Name: "Test User"}))
+	suite.mockMailer.AssertNotCalled(suite.T(), "SendEmail", mock.Anything, mock.Anything, mock.Anything)
+
+	// Security emails ignore opt-outs.
+	suite.mockMailer.On("LoadEmailTemplate", "new_sign_in", entity.DefaultLocale, mock.Anything).Return("body", nil)
+	suite.mockMailer.On("LoadEmailTemplate", "password_expiry_reminder", entity.DefaultLocale, mock.Anything).Return("body", nil)
+	suite.mockMailer.On("SendEmail", testEmail, mock.Anything, "body").Return(nil)
+	suite.NoError(suite.emailService.SendNewSignInEmail(testEmail, &services.NewSignInEmailData{Name: "Test User"}))
+	suite.NoError(suite.emailService.SendPasswordExpiryReminder(testEmail, &services.PasswordExpiryReminderData{Name: "Test User"}))
+	suite.mockMailer.AssertNumberOfCalls(suite.T(), "SendEmail", 2)
+}
@@ -0,0 +1,41 @@
+package test
+
+import (
+	"go-gin-hexagonal/internal/application/service"
+	"go-gin-hexagonal/pkg/config"
+	"go-gin-hexagonal/pkg/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistrationPolicyDisposable(t *testing.T) {
+	policy := service.NewRegistrationPolicy(config.RegistrationPolicyConfig{BlockDisposable: true})
+
+	assert.NoError(t, policy.CheckEmail("jane@example.com"))
+	assert.Equal(t, errors.ErrDisposableEmail, policy.CheckEmail("jane@mailinator.com"))
+	assert.Equal(t, errors.ErrDisposableEmail, policy.CheckEmail("jane@YOPMAIL.com"))
+	assert.Equal(t, errors.ErrDisposableEmail, policy.CheckEmail("jane@inbox.guerrillamail.com"))
+
+	permissive := service.NewRegistrationPolicy(config.RegistrationPolicyConfig{})
+	assert.NoError(t, permissive.CheckEmail("jane@mailinator.com"))
+}
+
+func TestRegistrationPolicyAllowAndDenyLists(t *testing.T) {
+	policy := service.NewRegistrationPolicy(config.RegistrationPolicyConfig{
+		AllowedDomains:  []string{"acme.com", "mailinator.com"},
+		BlockedDomains:  []string{"contractors.acme.com"},
+		BlockDisposable: true,
+	})
+
+	assert.NoError(t, policy.CheckEmail("jane@acme.com"))
+	assert.NoError(t, policy.CheckEmail("jane@eng.acme.com"))
+	assert.Equal(t, errors.ErrEmailDomainBlocked, policy.CheckEmail("bob@contractors.acme.com"))
+	assert.Equal(t, errors.ErrEmailDomainNotAllowed, policy.CheckEmail("jane@example.com"))
+	assert.Equal(t, errors.ErrEmailDomainNotAllowed, policy.CheckEmail("jane@notacme.com"))
+
+	// An explicit allow entry takes precedence over the disposable list
+	assert.NoError(t, policy.CheckEmail("qa@mailinator.com"))
+
+	assert.Equal(t, errors.ErrInvalidInput, policy.CheckEmail("not-an-email"))
+}
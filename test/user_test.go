@@ -2,15 +2,23 @@ package test
 
 import (
 	"context"
+	"fmt"
+	"go-gin-hexagonal/internal/adapter/http/handlers"
 	"go-gin-hexagonal/internal/application/service"
 	"go-gin-hexagonal/internal/domain/entity"
+	"go-gin-hexagonal/internal/domain/ports"
+	"go-gin-hexagonal/internal/domain/ports/repositories"
 	"go-gin-hexagonal/internal/domain/ports/services"
+	"go-gin-hexagonal/pkg/config"
 	"go-gin-hexagonal/pkg/errors"
 	mock_external "go-gin-hexagonal/test/mock/external"
 	mock_repository "go-gin-hexagonal/test/mock/repository"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -35,36 +43,122 @@ const (
 
 type UserTestSuite struct {
 	suite.Suite
-	mockRepo    *mock_repository.MockUserRepository
-	mockHasher  *mock_external.MockSecurityService
-	mockMailer  *mock_external.MockEmailService
-	userService services.UserService
-	ctx         context.Context
+	mockRepo           *mock_repository.MockUserRepository
+	mockInvitationRepo *mock_repository.MockInvitationRepository
+	mockRefreshTokens  *mock_repository.MockRefreshTokenRepository
+	mockHasher         *mock_external.MockSecurityService
+	mockMailer         *mock_external.MockEmailService
+	auditService       services.AuditService
+	userService        services.UserService
+	ctx                context.Context
 }
 
 func (suite *UserTestSuite) SetupTest() {
 	suite.mockRepo = mock_repository.NewMockUserRepository()
+	suite.mockInvitationRepo = mock_repository.NewMockInvitationRepository()
+	suite.mockRefreshTokens = mock_repository.NewMockRefreshTokenRepository()
 	suite.mockHasher = mock_external.NewMockSecurityService()
 	suite.mockMailer = mock_external.NewMockEmailService()
-	suite.userService = service.NewUserService(suite.mockRepo, suite.mockHasher, suite.mockMailer)
+	suite.auditService = service.NewAuditService(mock_repository.NewMockAuditEventRepository(), config.AuditConfig{})
+	suite.userService = newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:         suite.mockRepo,
+		Hasher:           suite.mockHasher,
+		Mailer:           suite.mockMailer,
+		InvitationRepo:   suite.mockInvitationRepo,
+		RefreshTokenRepo: suite.mockRefreshTokens,
+		Invitation:       config.InvitationConfig{Expiry: 72 * time.Hour},
+		PasswordPolicy:   config.PasswordPolicyConfig{MaxAge: 90 * 24 * time.Hour, ReminderBefore: 7 * 24 * time.Hour},
+		Registration:     config.RegistrationPolicyConfig{BlockDisposable: true},
+		Deletion:         config.DeletionConfig{Retention: 30 * 24 * time.Hour},
+		AuditService:     suite.auditService,
+	})
 	suite.ctx = context.Background()
 
 	// Setup common mock expectations
 	suite.mockHasher.On("Hash", mock.AnythingOfType("string")).Return("hashedpassword", nil)
 	suite.mockHasher.On("Verify", "hashedpassword", testPassword).Return(nil)
+	suite.mockMailer.On("SendInvitationEmail", mock.Anything, mock.Anything).Return(nil)
+	suite.mockMailer.On("SendPasswordExpiryReminder", mock.Anything, mock.Anything).Return(nil)
 }
 
 func TestUserTestSuite(t *testing.T) {
 	suite.Run(t, new(UserTestSuite))
 }
 
+// testUserServiceDeps overrides the dependencies newTestUserService builds a
+// UserService from; zero fields fall back to fresh mocks and empty configs.
+type testUserServiceDeps struct {
+	UserRepo         repositories.UserRepository
+	Hasher           ports.PasswordHasher
+	Mailer           services.EmailService
+	InvitationRepo   repositories.InvitationRepository
+	RefreshTokenRepo repositories.RefreshTokenRepository
+	Invitation       config.InvitationConfig
+	PasswordPolicy   config.PasswordPolicyConfig
+	Registration     config.RegistrationPolicyConfig
+	FileStorage      ports.FileStorage
+	AttributeRepo    repositories.AttributeDefinitionRepository
+	Deletion         config.DeletionConfig
+	AuditService     services.AuditService
+	UsernamePolicy   config.UsernamePolicyConfig
+}
+
+func newTestUserService(t *testing.T, deps testUserServiceDeps) services.UserService {
+	t.Helper()
+
+	if deps.UserRepo == nil {
+		deps.UserRepo = mock_repository.NewMockUserRepository()
+	}
+	if deps.Hasher == nil {
+		deps.Hasher = mock_external.NewMockSecurityService()
+	}
+	if deps.Mailer == nil {
+		mailer := mock_external.NewMockEmailService()
+		mailer.On("SendInvitationEmail", mock.Anything, mock.Anything).Return(nil)
+		deps.Mailer = mailer
+	}
+	if deps.InvitationRepo == nil {
+		deps.InvitationRepo = mock_repository.NewMockInvitationRepository()
+	}
+	if deps.RefreshTokenRepo == nil {
+		deps.RefreshTokenRepo = mock_repository.NewMockRefreshTokenRepository()
+	}
+	if deps.AttributeRepo == nil {
+		mockRepo, ok := deps.UserRepo.(*mock_repository.MockUserRepository)
+		if !ok {
+			t.Fatal("testUserServiceDeps: AttributeRepo is required with a custom UserRepo")
+		}
+		deps.AttributeRepo = mock_repository.NewMockAttributeDefinitionRepository(mockRepo)
+	}
+	if deps.AuditService == nil {
+		deps.AuditService = service.NewAuditService(mock_repository.NewMockAuditEventRepository(), config.AuditConfig{})
+	}
+
+	return service.NewUserService(
+		deps.UserRepo,
+		deps.Hasher,
+		deps.Mailer,
+		deps.InvitationRepo,
+		deps.RefreshTokenRepo,
+		deps.Invitation,
+		deps.PasswordPolicy,
+		service.NewRegistrationPolicy(deps.Registration),
+		deps.FileStorage,
+		deps.AttributeRepo,
+		deps.Deletion,
+		mock_external.NewMockEncryptor(),
+		deps.AuditService,
+		service.NewUsernamePolicy(deps.UsernamePolicy),
+	)
+}
+
 func createTestUser() *entity.User {
 	return &entity.User{
 		Email:    testEmail,
 		Username: testUsername,
 		Password: testPassword,
 		Name:     testName,
-		IsActive: true,
+		Status:   entity.UserStatusActive,
 		AuditInfo: entity.AuditInfo{
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -102,6 +196,7 @@ func (suite *UserTestSuite) TestUserRepoCRUD() {
 	// Update
 	updatedUser := createTestUserWithID(userID)
 	updatedUser.Name = updatedName
+	updatedUser.Version = retrievedUser.Version
 	_, err = suite.mockRepo.Update(suite.ctx, updatedUser)
 	suite.NoError(err)
 
@@ -132,7 +227,7 @@ func (suite *UserTestSuite) TestUserRepoFO() {
 	})
 
 	suite.Run("FindAll", func() {
-		users, total, err := suite.mockRepo.FindAll(suite.ctx, 10, 0, "")
+		users, total, err := suite.mockRepo.FindAll(suite.ctx, &repositories.UserFilter{}, nil, 10, 0)
 		suite.NoError(err)
 		suite.GreaterOrEqual(total, int64(1))
 		suite.LessOrEqual(len(users), 10)
@@ -219,10 +314,644 @@ func (suite *UserTestSuite) TestUserServiceCL() {
 	suite.NoError(err)
 
 	// Delete
-	err = suite.userService.DeleteUser(suite.ctx, userID)
+	err = suite.userService.DeleteUser(suite.ctx, uuid.New(), userID)
 	suite.NoError(err)
 
 	// Verify deletion
 	_, err = suite.userService.GetUserByID(suite.ctx, userID)
 	suite.Equal(errors.ErrUserNotFound, err)
 }
+
+func (suite *UserTestSuite) TestUserServiceInvitation() {
+	email := "invited@example.com"
+
+	// Create sends an invitation instead of a generated password
+	userInfo, err := suite.userService.CreateUser(suite.ctx, &services.CreateUserRequest{
+		Email: email,
+		Name:  "Invited User",
+	})
+	suite.NoError(err)
+	suite.Equal(entity.UserStatusPendingVerification, userInfo.Status)
+
+	user, err := suite.mockRepo.FindByEmail(suite.ctx, email)
+	suite.NoError(err)
+	suite.Empty(user.Password)
+	suite.True(user.FirstLoginPending)
+
+	first, err := suite.mockInvitationRepo.FindPendingByUserID(suite.ctx, user.ID)
+	suite.NoError(err)
+
+	// Resend replaces the pending invitation
+	suite.NoError(suite.userService.ResendInvitation(suite.ctx, user.ID))
+	suite.NotNil(first.RevokedAt)
+
+	second, err := suite.mockInvitationRepo.FindPendingByUserID(suite.ctx, user.ID)
+	suite.NoError(err)
+	suite.NotEqual(first.ID, second.ID)
+
+	// Revoke leaves no pending invitation behind
+	suite.NoError(suite.userService.RevokeInvitation(suite.ctx, user.ID))
+	_, err = suite.mockInvitationRepo.FindPendingByUserID(suite.ctx, user.ID)
+	suite.Equal(errors.ErrInvitationNotFound, err)
+
+	suite.Equal(errors.ErrInvitationNotFound, suite.userService.RevokeInvitation(suite.ctx, user.ID))
+
+	// Deactivating an invited user revokes the invitation they still hold.
+	suite.NoError(suite.userService.ResendInvitation(suite.ctx, user.ID))
+	_, err = suite.userService.DeactivateUser(suite.ctx, uuid.New(), user.ID, "left the company")
+	suite.NoError(err)
+	_, err = suite.mockInvitationRepo.FindPendingByUserID(suite.ctx, user.ID)
+	suite.Equal(errors.ErrInvitationNotFound, err)
+}
+
+func (suite *UserTestSuite) TestUserServicePasswordExpiry() {
+	user := createTestUser()
+	user.ID = uuid.New()
+	user.Email = "expiring@example.com"
+	user.Username = "expiring"
+	user.Password = "hashedpassword"
+	changedAt := time.Now().Add(-85 * 24 * time.Hour)
+	user.PasswordChangedAt = &changedAt
+	_, err := suite.mockRepo.Create(suite.ctx, user)
+	suite.NoError(err)
+
+	// Passwords inside the reminder window are reminded exactly once
+	suite.NoError(suite.userService.SendPasswordExpiryReminders(suite.ctx))
+	suite.mockMailer.AssertNumberOfCalls(suite.T(), "SendPasswordExpiryReminder", 1)
+	suite.NotNil(user.PasswordReminderSentAt)
+
+	suite.NoError(suite.userService.SendPasswordExpiryReminders(suite.ctx))
+	suite.mockMailer.AssertNumberOfCalls(suite.T(), "SendPasswordExpiryReminder", 1)
+
+	// Reusing the current password is rejected
+	err = suite.userService.ChangePassword(suite.ctx, user.ID, &services.ChangePasswordRequest{
+		CurrentPassword: testPassword,
+		NewPassword:     testPassword,
+	})
+	suite.Equal(errors.ErrPasswordReused, err)
+
+	// Changing the password resets the expiry state
+	user.MustChangePassword = true
+	err = suite.userService.ChangePassword(suite.ctx, user.ID, &services.ChangePasswordRequest{
+		CurrentPassword: testPassword,
+		NewPassword:     "newpassword123",
+	})
+	suite.NoError(err)
+	suite.False(user.MustChangePassword)
+	suite.Nil(user.PasswordReminderSentAt)
+	suite.True(user.PasswordChangedAt.After(changedAt))
+}
+
+func (suite *UserTestSuite) TestUserServicePasswordExpiryRemindersContinue() {
+	changedAt := time.Now().Add(-85 * 24 * time.Hour)
+	var users []*entity.User
+	for i := range 3 {
+		user := createTestUser()
+		user.Email = fmt.Sprintf("expiring%d@example.com", i)
+		user.Username = fmt.Sprintf("expiring%d", i)
+		user.Password = "hashedpassword"
+		user.PasswordChangedAt = &changedAt
+		_, err := suite.mockRepo.Create(suite.ctx, user)
+		suite.Require().NoError(err)
+		users = append(users, user)
+	}
+
+	userService := newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:       &failingUpdateUserRepository{MockUserRepository: suite.mockRepo, userID: users[1].ID},
+		Mailer:         suite.mockMailer,
+		AttributeRepo:  mock_repository.NewMockAttributeDefinitionRepository(suite.mockRepo),
+		PasswordPolicy: config.PasswordPolicyConfig{MaxAge: 90 * 24 * time.Hour, ReminderBefore: 7 * 24 * time.Hour},
+	})
+
+	// One failed update does not keep the other users from being reminded.
+	err := userService.SendPasswordExpiryReminders(suite.ctx)
+	suite.ErrorIs(err, errors.ErrUpdateUser)
+	suite.mockMailer.AssertNumberOfCalls(suite.T(), "SendPasswordExpiryReminder", 3)
+	suite.NotNil(users[0].PasswordReminderSentAt)
+	suite.NotNil(users[2].PasswordReminderSentAt)
+}
+
+func (suite *UserTestSuite) TestUserServiceRegistrationPolicy() {
+	_, err := suite.userService.CreateUser(suite.ctx, &services.CreateUserRequest{
+		Email: "throwaway@mailinator.com",
+		Name:  "Throwaway User",
+	})
+	suite.Equal(errors.ErrDisposableEmail, err)
+	suite.False(suite.mockRepo.ExistsByEmail(suite.ctx, "throwaway@mailinator.com"))
+}
+
+func (suite *UserTestSuite) TestUserStatusTransitions() {
+	now := time.Now()
+	user := &entity.User{Status: entity.UserStatusPendingVerification}
+
+	suite.ErrorIs(user.TransitionTo(entity.UserStatusSuspended, "", now), errors.ErrInvalidStatusTransition)
+	suite.Equal(entity.UserStatusPendingVerification, user.Status)
+
+	suite.NoError(user.TransitionTo(entity.UserStatusActive, "", now))
+	suite.True(user.IsActive())
+
+	suite.NoError(user.TransitionTo(entity.UserStatusSuspended, "chargeback", now))
+	suite.Equal("chargeback", user.StatusReason)
+	suite.Equal(now, *user.StatusChangedAt)
+	suite.ErrorIs(user.TransitionTo(entity.UserStatusPendingVerification, "", now), errors.ErrInvalidStatusTransition)
+
+	suite.NoError(user.TransitionTo(entity.UserStatusDeactivated, "", now))
+	suite.ErrorIs(user.TransitionTo(entity.UserStatusPendingVerification, "", now), errors.ErrInvalidStatusTransition)
+	suite.NoError(user.TransitionTo(entity.UserStatusActive, "", now))
+}
+
+func (suite *UserTestSuite) TestUserServiceSuspension() {
+	adminID := uuid.New()
+	user, err := suite.mockRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.mockRefreshTokens.Save(suite.ctx, &entity.RefreshToken{
+		UserID:    user.ID,
+		Token:     "refresh-token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	notifications := make(chan string, 3)
+	suite.mockMailer.On("SendAccountSuspendedEmail", testEmail, mock.Anything).
+		Run(func(args mock.Arguments) { notifications <- entity.UserStatusSuspended }).
+		Return(nil)
+	suite.mockMailer.On("SendAccountReinstatedEmail", testEmail, mock.Anything).
+		Run(func(args mock.Arguments) { notifications <- entity.UserStatusActive }).
+		Return(nil)
+	expectNotification := func(expected string) {
+		select {
+		case status := <-notifications:
+			suite.Equal(expected, status)
+		case <-time.After(time.Second):
+			suite.FailNow("expected a notification email")
+		}
+	}
+
+	past := time.Now().Add(-time.Minute)
+	_, err = suite.userService.SuspendUser(suite.ctx, adminID, user.ID, &services.SuspendUserRequest{Reason: "spam", Until: &past})
+	suite.ErrorIs(err, errors.ErrInvalidInput)
+
+	_, err = suite.userService.SuspendUser(suite.ctx, adminID, adminID, &services.SuspendUserRequest{Reason: "spam"})
+	suite.ErrorIs(err, errors.ErrSuspendSelf)
+
+	// Suspension revokes every session and notifies the user.
+	until := time.Now().Add(time.Hour)
+	info, err := suite.userService.SuspendUser(suite.ctx, adminID, user.ID, &services.SuspendUserRequest{Reason: "spam", Until: &until})
+	suite.Require().NoError(err)
+	suite.Equal(entity.UserStatusSuspended, info.Status)
+	suite.Equal("spam", info.StatusReason)
+	suite.Equal(until, *info.SuspendedUntil)
+	suite.False(suite.mockRefreshTokens.IsTokenValid(suite.ctx, "refresh-token"))
+	expectNotification(entity.UserStatusSuspended)
+
+	info, err = suite.userService.UnsuspendUser(suite.ctx, adminID, user.ID)
+	suite.Require().NoError(err)
+	suite.Equal(entity.UserStatusActive, info.Status)
+	suite.Nil(info.SuspendedUntil)
+	expectNotification(entity.UserStatusActive)
+
+	_, err = suite.userService.UnsuspendUser(suite.ctx, adminID, user.ID)
+	suite.ErrorIs(err, errors.ErrUserNotSuspended)
+
+	// Indefinite suspensions are never lifted automatically.
+	_, err = suite.userService.SuspendUser(suite.ctx, adminID, user.ID, &services.SuspendUserRequest{Reason: "abuse"})
+	suite.Require().NoError(err)
+	expectNotification(entity.UserStatusSuspended)
+	suite.NoError(suite.userService.LiftExpiredSuspensions(suite.ctx))
+	suite.Equal(entity.UserStatusSuspended, user.Status)
+
+	user.SuspendedUntil = &past
+	suite.NoError(suite.userService.LiftExpiredSuspensions(suite.ctx))
+	suite.Equal(entity.UserStatusActive, user.Status)
+	expectNotification(entity.UserStatusActive)
+
+	// Every admin action is audited with the suspension reason.
+	result, err := suite.auditService.ListEvents(suite.ctx, &services.ListAuditEventsRequest{Page: 1, PageSize: 20, ActorID: &adminID})
+	suite.Require().NoError(err)
+	reasons := make(map[string][]string)
+	for _, event := range result.Datas {
+		reasons[event.EventType] = append(reasons[event.EventType], event.Reason)
+	}
+	suite.ElementsMatch([]string{errors.ErrInvalidInput.Error(), errors.ErrSuspendSelf.Error(), "spam", "abuse"}, reasons[entity.AuditEventAdminUserSuspended])
+	suite.ElementsMatch([]string{"", errors.ErrUserNotSuspended.Error()}, reasons[entity.AuditEventAdminUserUnsuspended])
+}
+
+// failingUpdateUserRepository fails every update of one user.
+type failingUpdateUserRepository struct {
+	*mock_repository.MockUserRepository
+	userID uuid.UUID
+}
+
+func (r *failingUpdateUserRepository) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if user.ID == r.userID {
+		return nil, errors.ErrUpdateUser
+	}
+	return r.MockUserRepository.Update(ctx, user)
+}
+
+func (suite *UserTestSuite) TestUserServiceLiftExpiredSuspensionsContinues() {
+	past := time.Now().Add(-time.Minute)
+	var users []*entity.User
+	for i := range 3 {
+		user := createTestUser()
+		user.Email = fmt.Sprintf("suspended%d@example.com", i)
+		user.Username = fmt.Sprintf("suspended%d", i)
+		suite.Require().NoError(user.Suspend("spam", &past, time.Now()))
+		_, err := suite.mockRepo.Create(suite.ctx, user)
+		suite.Require().NoError(err)
+		users = append(users, user)
+	}
+	suite.mockMailer.On("SendAccountReinstatedEmail", mock.Anything, mock.Anything).Return(nil)
+
+	userService := newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:      &failingUpdateUserRepository{MockUserRepository: suite.mockRepo, userID: users[1].ID},
+		Mailer:        suite.mockMailer,
+		AttributeRepo: mock_repository.NewMockAttributeDefinitionRepository(suite.mockRepo),
+	})
+
+	err := userService.LiftExpiredSuspensions(suite.ctx)
+	suite.ErrorIs(err, errors.ErrUpdateUser)
+	suite.Equal(entity.UserStatusActive, users[0].Status)
+	suite.Equal(entity.UserStatusActive, users[2].Status)
+}
+
+func (suite *UserTestSuite) TestUserServiceUpdateVersion() {
+	user, err := suite.mockRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+
+	info, err := suite.userService.GetUserByID(suite.ctx, user.ID)
+	suite.Require().NoError(err)
+	suite.Equal(1, info.Version)
+
+	name := updatedName
+	updated, err := suite.userService.UpdateUser(suite.ctx, user.ID, &services.UpdateUserRequest{Name: &name, Versions: []int{info.Version}})
+	suite.Require().NoError(err)
+	suite.Equal(2, updated.Version)
+
+	// A second writer still holding version 1 must not overwrite the change.
+	stale := "Stale Name"
+	_, err = suite.userService.UpdateUser(suite.ctx, user.ID, &services.UpdateUserRequest{Name: &stale, Versions: []int{info.Version}})
+	suite.ErrorIs(err, errors.ErrVersionConflict)
+
+	current, err := suite.userService.GetUserByID(suite.ctx, user.ID)
+	suite.Require().NoError(err)
+	suite.Equal(updatedName, current.Name)
+
+	// Any of several listed versions matches.
+	updated, err = suite.userService.UpdateUser(suite.ctx, user.ID, &services.UpdateUserRequest{Name: &name, Versions: []int{info.Version, updated.Version}})
+	suite.Require().NoError(err)
+	suite.Equal(3, updated.Version)
+
+	// Unconditional updates still apply.
+	_, err = suite.userService.UpdateUser(suite.ctx, user.ID, &services.UpdateUserRequest{Name: &stale})
+	suite.NoError(err)
+}
+
+func (suite *UserTestSuite) TestUserServiceUsernamePolicy() {
+	userService := newTestUserService(suite.T(), testUserServiceDeps{
+		UserRepo:         suite.mockRepo,
+		Hasher:           suite.mockHasher,
+		Mailer:           suite.mockMailer,
+		InvitationRepo:   suite.mockInvitationRepo,
+		RefreshTokenRepo: suite.mockRefreshTokens,
+		Invitation:       config.InvitationConfig{Expiry: 72 * time.Hour},
+		AuditService:     suite.auditService,
+		UsernamePolicy: config.UsernamePolicyConfig{
+			Reserved:       []string{"admin", "support"},
+			ChangeCooldown: 24 * time.Hour,
+			AliasRetention: 48 * time.Hour,
+		},
+	})
+	user, err := suite.mockRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+	username := func(value string) *services.UpdateUserRequest {
+		return &services.UpdateUserRequest{Username: &value}
+	}
+
+	_, err = userService.UpdateUser(suite.ctx, user.ID, username("Admin"))
+	suite.Equal(errors.ErrUsernameReserved, err)
+
+	updated, err := userService.UpdateUser(suite.ctx, user.ID, username("renamed_user"))
+	suite.Require().NoError(err)
+	suite.Equal("renamed_user", updated.Username)
+
+	// The old username still finds the user and cannot be taken by others.
+	found, err := userService.GetUserByUsername(suite.ctx, testUsername)
+	suite.Require().NoError(err)
+	suite.Equal(user.ID, found.ID)
+	suite.Equal("renamed_user", found.Username)
+
+	other, err := userService.CreateUser(suite.ctx, &services.CreateUserRequest{Email: "other@example.com", Name: "Other User"})
+	suite.Require().NoError(err)
+	_, err = userService.UpdateUser(suite.ctx, other.ID, username(testUsername))
+	suite.Equal(errors.ErrUserAlreadyExists, err)
+
+	_, err = userService.UpdateUser(suite.ctx, user.ID, username("renamed_again"))
+	suite.Equal(errors.ErrUsernameChangeCooldown, err)
+
+	// Admins are held to neither rule and users may take back an alias.
+	adminID := uuid.New()
+	_, err = userService.UpdateUserByAdmin(suite.ctx, adminID, user.ID, username(testUsername))
+	suite.Require().NoError(err)
+	_, err = userService.UpdateUserByAdmin(suite.ctx, adminID, user.ID, username("support"))
+	suite.Require().NoError(err)
+
+	found, err = userService.GetUserByUsername(suite.ctx, "renamed_user")
+	suite.Require().NoError(err)
+	suite.Equal("support", found.Username)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/users/username/:username", handlers.NewUserHandler(userService).GetUserByUsername)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/username/renamed_user", nil))
+	suite.Equal(http.StatusTemporaryRedirect, recorder.Code)
+	suite.Equal("/users/username/support", recorder.Header().Get("Location"))
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/username/support", nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// Expired aliases stop resolving and free the username.
+	deleted, err := suite.mockRepo.DeleteExpiredUsernameAliases(suite.ctx, time.Now().Add(72*time.Hour))
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), deleted)
+	_, err = userService.GetUserByUsername(suite.ctx, testUsername)
+	suite.Equal(errors.ErrUserNotFound, err)
+	_, err = userService.UpdateUser(suite.ctx, other.ID, username(testUsername))
+	suite.NoError(err)
+}
+
+func (suite *UserTestSuite) TestUserServiceAdminManagement() {
+	adminID := uuid.New()
+	user, err := suite.mockRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+
+	emails := make(chan string, 3)
+	suite.mockMailer.On("SendRequestResetPassword", testEmail, mock.Anything).
+		Run(func(args mock.Arguments) { emails <- args.Get(1).(*services.ResetPasswordData).ResetLink }).
+		Return(nil)
+	suite.mockMailer.On("SendVerifyEmail", "pending@example.com", mock.Anything).
+		Run(func(args mock.Arguments) { emails <- args.Get(1).(*services.VerifyEmailData).VerificationURL }).
+		Return(nil)
+	suite.mockMailer.On("SendAccountReinstatedEmail", testEmail, mock.Anything).
+		Run(func(args mock.Arguments) { emails <- entity.UserStatusActive }).
+		Return(nil)
+	expectEmail := func() string {
+		select {
+		case email := <-emails:
+			return email
+		case <-time.After(time.Second):
+			suite.FailNow("expected an email")
+			return ""
+		}
+	}
+
+	name := updatedName
+	updated, err := suite.userService.UpdateUserByAdmin(suite.ctx, adminID, user.ID, &services.UpdateUserRequest{Name: &name})
+	suite.Require().NoError(err)
+	suite.Equal(updatedName, updated.Name)
+
+	// A reset ends every session and forces a new password at next sign-in.
+	suite.Require().NoError(suite.mockRefreshTokens.Save(suite.ctx, &entity.RefreshToken{
+		UserID:    user.ID,
+		Token:     "refresh-token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+	suite.Require().NoError(suite.userService.ResetUserPassword(suite.ctx, adminID, user.ID))
+	suite.True(user.MustChangePassword)
+	suite.False(suite.mockRefreshTokens.IsTokenValid(suite.ctx, "refresh-token"))
+	suite.Contains(expectEmail(), "/reset-password?token=")
+
+	suite.ErrorIs(suite.userService.ResendVerification(suite.ctx, adminID, user.ID), errors.ErrUserVerified)
+
+	pending := createTestUser()
+	pending.Email = "pending@example.com"
+	pending.Username = "pending"
+	pending.Status = entity.UserStatusPendingVerification
+	_, err = suite.mockRepo.Create(suite.ctx, pending)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.userService.ResendVerification(suite.ctx, adminID, pending.ID))
+	suite.Contains(expectEmail(), "/verify-email?token=")
+
+	_, err = suite.userService.DeactivateUser(suite.ctx, adminID, adminID, "leaving")
+	suite.ErrorIs(err, errors.ErrDeactivateSelf)
+
+	deactivated, err := suite.userService.DeactivateUser(suite.ctx, adminID, user.ID, "left the company")
+	suite.Require().NoError(err)
+	suite.Equal(entity.UserStatusDeactivated, deactivated.Status)
+	suite.Equal("left the company", deactivated.StatusReason)
+
+	activated, err := suite.userService.ActivateUser(suite.ctx, adminID, user.ID)
+	suite.Require().NoError(err)
+	suite.Equal(entity.UserStatusActive, activated.Status)
+	suite.Equal(entity.UserStatusActive, expectEmail())
+
+	_, err = suite.userService.ActivateUser(suite.ctx, adminID, user.ID)
+	suite.ErrorIs(err, errors.ErrInvalidStatusTransition)
+
+	// Accounts that were never active are not told they were reinstated.
+	suite.mockMailer.On("SendAccountReinstatedEmail", "pending@example.com", mock.Anything).
+		Run(func(args mock.Arguments) { emails <- "pending@example.com" }).
+		Return(nil)
+	activated, err = suite.userService.ActivateUser(suite.ctx, adminID, pending.ID)
+	suite.Require().NoError(err)
+	suite.Equal(entity.UserStatusActive, activated.Status)
+	select {
+	case email := <-emails:
+		suite.Failf("unexpected email", "%s", email)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Every action is audited against the admin, including refused ones.
+	result, err := suite.auditService.ListEvents(suite.ctx, &services.ListAuditEventsRequest{Page: 1, PageSize: 20, ActorID: &adminID})
+	suite.Require().NoError(err)
+	outcomes := make(map[string][]string)
+	for _, event := range result.Datas {
+		outcomes[event.EventType] = append(outcomes[event.EventType], event.Outcome)
+		if event.EventType == entity.AuditEventAdminUserUpdated {
+			suite.Equal("updated name", event.Reason)
+		}
+	}
+	suite.Equal([]string{entity.AuditOutcomeSuccess}, outcomes[entity.AuditEventAdminUserUpdated])
+	suite.Equal([]string{entity.AuditOutcomeSuccess}, outcomes[entity.AuditEventAdminPasswordReset])
+	suite.ElementsMatch([]string{entity.AuditOutcomeSuccess, entity.AuditOutcomeFailure}, outcomes[entity.AuditEventAdminVerificationResent])
+	suite.ElementsMatch([]string{entity.AuditOutcomeSuccess, entity.AuditOutcomeFailure}, outcomes[entity.AuditEventAdminUserDeactivated])
+	suite.ElementsMatch([]string{entity.AuditOutcomeSuccess, entity.AuditOutcomeFailure, entity.AuditOutcomeSuccess}, outcomes[entity.AuditEventAdminUserActivated])
+}
+
+func (suite *UserTestSuite) TestUserServiceSoftDelete() {
+	adminID := uuid.New()
+	user, err := suite.mockRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.mockRefreshTokens.Save(suite.ctx, &entity.RefreshToken{
+		UserID:    user.ID,
+		Token:     "refresh-token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	_, err = suite.userService.RestoreUser(suite.ctx, adminID, user.ID)
+	suite.ErrorIs(err, errors.ErrUserNotDeleted)
+
+	suite.ErrorIs(suite.userService.DeleteUser(suite.ctx, adminID, adminID), errors.ErrDeleteSelf)
+
+	// Deleting hides the user and ends their sessions.
+	suite.Require().NoError(suite.userService.DeleteUser(suite.ctx, adminID, user.ID))
+	suite.True(user.IsDeleted)
+	suite.NotNil(user.DeletedAt)
+	suite.False(suite.mockRefreshTokens.IsTokenValid(suite.ctx, "refresh-token"))
+	suite.ErrorIs(suite.userService.DeleteUser(suite.ctx, adminID, user.ID), errors.ErrUserNotFound)
+
+	_, err = suite.userService.GetUserByID(suite.ctx, user.ID)
+	suite.ErrorIs(err, errors.ErrUserNotFound)
+
+	listed, err := suite.userService.GetAllUsers(suite.ctx, &services.ListUsersRequest{Page: 1, PageSize: 10})
+	suite.Require().NoError(err)
+	suite.Empty(listed.Datas)
+
+	listed, err = suite.userService.GetAllUsers(suite.ctx, &services.ListUsersRequest{Page: 1, PageSize: 10, IncludeDeleted: true})
+	suite.Require().NoError(err)
+	suite.Require().Len(listed.Datas, 1)
+	suite.NotNil(listed.Datas[0].DeletedAt)
+
+	// The email stays taken until the user is purged.
+	_, err = suite.userService.CreateUser(suite.ctx, &services.CreateUserRequest{Email: testEmail, Name: testName})
+	suite.ErrorIs(err, errors.ErrUserAlreadyExists)
+
+	restored, err := suite.userService.RestoreUser(suite.ctx, adminID, user.ID)
+	suite.Require().NoError(err)
+	suite.Nil(restored.DeletedAt)
+	suite.False(user.IsDeleted)
+
+	_, err = suite.userService.RestoreUser(suite.ctx, adminID, uuid.New())
+	suite.ErrorIs(err, errors.ErrUserNotFound)
+
+	result, err := suite.auditService.ListEvents(suite.ctx, &services.ListAuditEventsRequest{Page: 1, PageSize: 20, ActorID: &adminID})
+	suite.Require().NoError(err)
+	outcomes := make(map[string][]string)
+	for _, event := range result.Datas {
+		outcomes[event.EventType] = append(outcomes[event.EventType], event.Outcome)
+	}
+	suite.ElementsMatch([]string{entity.AuditOutcomeFailure, entity.AuditOutcomeSuccess, entity.AuditOutcomeFailure}, outcomes[entity.AuditEventAdminUserDeleted])
+	suite.ElementsMatch([]string{entity.AuditOutcomeFailure, entity.AuditOutcomeSuccess, entity.AuditOutcomeFailure}, outcomes[entity.AuditEventAdminUserRestored])
+}
+
+func (suite *UserTestSuite) TestUserServicePurgeDeletedUsers() {
+	adminID := uuid.New()
+	expired, err := suite.mockRepo.FindByEmail(suite.ctx, testEmail)
+	suite.Require().NoError(err)
+
+	recent := createTestUser()
+	recent.Email = "recent@example.com"
+	recent.Username = "recent"
+	_, err = suite.mockRepo.Create(suite.ctx, recent)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.userService.DeleteUser(suite.ctx, adminID, expired.ID))
+	suite.Require().NoError(suite.userService.DeleteUser(suite.ctx, adminID, recent.ID))
+	longAgo := time.Now().Add(-31 * 24 * time.Hour)
+	expired.DeletedAt = &longAgo
+
+	suite.Require().NoError(suite.userService.PurgeDeletedUsers(suite.ctx))
+
+	_, err = suite.userService.RestoreUser(suite.ctx, adminID, expired.ID)
+	suite.ErrorIs(err, errors.ErrUserNotFound)
+	suite.False(suite.mockRepo.ExistsByEmail(suite.ctx, testEmail))
+
+	_, err = suite.userService.RestoreUser(suite.ctx, adminID, recent.ID)
+	suite.NoError(err)
+}
+
+func (suite *UserTestSuite) TestUserServiceListFilters() {
+	now := time.Now()
+	for i, status := range []string{entity.UserStatusActive, entity.UserStatusSuspended, entity.UserStatusPendingVerification} {
+		_, err := suite.mockRepo.Create(suite.ctx, &entity.User{
+			ID:       uuid.New(),
+			Email:    fmt.Sprintf("filter%d@example.com", i),
+			Username: fmt.Sprintf("filter%d", i),
+			Name:     fmt.Sprintf("Filter %d", i),
+			Status:   status,
+			AuditInfo: entity.AuditInfo{
+				CreatedAt: now.Add(time.Duration(i+1) * -24 * time.Hour),
+				UpdatedAt: now,
+			},
+		})
+		suite.Require().NoError(err)
+	}
+
+	list := func(req *services.ListUsersRequest) []string {
+		req.Page, req.PageSize = 1, 10
+		result, err := suite.userService.GetAllUsers(suite.ctx, req)
+		suite.Require().NoError(err)
+
+		var usernames []string
+		for _, user := range result.Datas {
+			usernames = append(usernames, user.Username)
+		}
+		return usernames
+	}
+
+	suite.Run("DefaultNewestFirst", func() {
+		suite.Equal([]string{testUsername, "filter0", "filter1", "filter2"}, list(&services.ListUsersRequest{}))
+	})
+
+	suite.Run("SortAscending", func() {
+		suite.Equal([]string{"filter0", "filter1", "filter2", testUsername}, list(&services.ListUsersRequest{Sort: "username", Order: "asc"}))
+	})
+
+	suite.Run("Status", func() {
+		suite.Equal([]string{"filter1"}, list(&services.ListUsersRequest{Status: entity.UserStatusSuspended}))
+	})
+
+	suite.Run("IsActive", func() {
+		inactive := false
+		suite.Equal([]string{"filter1", "filter2"}, list(&services.ListUsersRequest{IsActive: &inactive}))
+	})
+
+	suite.Run("CreatedRange", func() {
+		from, to := now.Add(-50*time.Hour), now.Add(-time.Hour)
+		suite.Equal([]string{"filter0", "filter1"}, list(&services.ListUsersRequest{CreatedFrom: &from, CreatedTo: &to}))
+	})
+
+	suite.Run("Search", func() {
+		suite.Equal([]string{"filter1"}, list(&services.ListUsersRequest{Search: "  FILTER 1 "}))
+		suite.Equal([]string{"filter0", "filter1", "filter2"}, list(&services.ListUsersRequest{Search: "Filter", Sort: "username"}))
+	})
+
+	suite.Run("ExactEmail", func() {
+		suite.Equal([]string{"filter2"}, list(&services.ListUsersRequest{Email: "filter2@example.com"}))
+	})
+
+	suite.Run("Cursor", func() {
+		first := ""
+		req := &services.ListUsersRequest{PageSize: 3, Sort: "username", Cursor: &first}
+		page, err := suite.userService.GetAllUsers(suite.ctx, req)
+		suite.Require().NoError(err)
+		suite.Len(page.Datas, 3)
+		suite.Empty(page.PrevCursor)
+		suite.NotEmpty(page.NextCursor)
+		suite.Zero(page.Total)
+
+		req.Cursor = &page.NextCursor
+		page, err = suite.userService.GetAllUsers(suite.ctx, req)
+		suite.Require().NoError(err)
+		suite.Equal(testUsername, page.Datas[0].Username)
+		suite.Empty(page.NextCursor)
+		suite.NotEmpty(page.PrevCursor)
+
+		req.Cursor = &page.PrevCursor
+		page, err = suite.userService.GetAllUsers(suite.ctx, req)
+		suite.Require().NoError(err)
+		suite.Equal("filter0", page.Datas[0].Username)
+
+		invalid := "not-a-cursor"
+		req.Cursor = &invalid
+		_, err = suite.userService.GetAllUsers(suite.ctx, req)
+		suite.ErrorIs(err, errors.ErrInvalidCursor)
+	})
+
+	suite.Run("UnknownSortField", func() {
+		_, err := suite.userService.GetAllUsers(suite.ctx, &services.ListUsersRequest{Page: 1, PageSize: 10, Sort: "password"})
+		suite.ErrorIs(err, errors.ErrInvalidSortField)
+	})
+}